@@ -0,0 +1,104 @@
+package tangled
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Age bucket labels, from newest to oldest
+const (
+	AgeUnder6Months = "<6mo"
+	Age6To12Months  = "6-12mo"
+	AgeOver1Year    = ">1y"
+	AgeUnknown      = "unknown"
+)
+
+// AgeBucket groups the modules whose versions fall within an age range
+type AgeBucket struct {
+	Label   string
+	Modules []Module
+}
+
+// ParseModuleDatesFromFile reads a module date file and returns release dates
+// keyed by module path or path@version
+func ParseModuleDatesFromFile(filename string) (map[string]time.Time, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseModuleDates(file)
+}
+
+// ParseModuleDates reads "module date" lines, where module is a path or
+// path@version and date is YYYY-MM-DD or RFC 3339, and returns the dates keyed
+// by module. Blank lines and lines starting with # are ignored.
+func ParseModuleDates(reader io.Reader) (map[string]time.Time, error) {
+	pairs, err := parsePairs(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make(map[string]time.Time, len(pairs))
+	for module, value := range pairs {
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			if date, err = time.Parse(time.RFC3339, value); err != nil {
+				return nil, fmt.Errorf("invalid date %q for %s: expected YYYY-MM-DD or RFC 3339", value, module)
+			}
+		}
+		dates[module] = date.UTC()
+	}
+	return dates, nil
+}
+
+// VersionDate returns when a module's version was published, preferring a
+// path@version entry in dates, then a path entry, then the time embedded in
+// a pseudo-version
+func (m Module) VersionDate(dates map[string]time.Time) (time.Time, bool) {
+	if date, ok := dates[m.String()]; ok {
+		return date, true
+	}
+	if date, ok := dates[m.Path]; ok {
+		return date, true
+	}
+	return m.Date()
+}
+
+// AgeHistogram buckets every dependency by how old its version was at now:
+// under six months, six to twelve months, over a year, and unknown for
+// tagged versions with no date in dates. The main module is not counted.
+// Buckets are returned in that order, each sorted by module.
+func (dg *DependencyGraph) AgeHistogram(now time.Time, dates map[string]time.Time) []AgeBucket {
+	buckets := []AgeBucket{
+		{Label: AgeUnder6Months},
+		{Label: Age6To12Months},
+		{Label: AgeOver1Year},
+		{Label: AgeUnknown},
+	}
+	sixMonthsAgo := now.AddDate(0, -6, 0)
+	oneYearAgo := now.AddDate(-1, 0, 0)
+
+	main := dg.MainModule.String()
+	for _, module := range dg.GetAllModules() {
+		if module.String() == main {
+			continue
+		}
+
+		date, ok := module.VersionDate(dates)
+		var index int
+		switch {
+		case !ok:
+			index = 3
+		case date.Before(oneYearAgo):
+			index = 2
+		case date.Before(sixMonthsAgo):
+			index = 1
+		}
+		buckets[index].Modules = append(buckets[index].Modules, module)
+	}
+	return buckets
+}
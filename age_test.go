@@ -0,0 +1,82 @@
+package tangled
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseModuleDates(t *testing.T) {
+	input := `# release dates
+github.com/dep1 2024-03-01
+
+github.com/dep2@v1.2.0 2023-01-15T10:30:00Z`
+
+	dates, err := ParseModuleDates(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseModuleDates() error = %v", err)
+	}
+
+	want := map[string]time.Time{
+		"github.com/dep1":        time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"github.com/dep2@v1.2.0": time.Date(2023, 1, 15, 10, 30, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(dates, want) {
+		t.Errorf("ParseModuleDates() = %v, want %v", dates, want)
+	}
+
+	if _, err := ParseModuleDates(strings.NewReader("github.com/dep1 yesterday")); err == nil {
+		t.Error("ParseModuleDates() should reject unparseable dates")
+	}
+}
+
+func TestDependencyGraph_AgeHistogram(t *testing.T) {
+	main := Module{Path: "example.com/main"}
+	graph := NewDependencyGraph(main)
+	fresh := Module{Path: "github.com/fresh", Version: "v0.0.0-20240501000000-abcdef123456"}
+	aging := Module{Path: "github.com/aging", Version: "v0.0.0-20231101000000-abcdef123456"}
+	stale := Module{Path: "github.com/stale", Version: "v1.0.0"}
+	tagged := Module{Path: "github.com/tagged", Version: "v2.3.0"}
+	graph.AddDependency(main, fresh)
+	graph.AddDependency(main, aging)
+	graph.AddDependency(main, stale)
+	graph.AddDependency(main, tagged)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	dates := map[string]time.Time{"github.com/stale": time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	got := make(map[string][]Module)
+	var labels []string
+	for _, bucket := range graph.AgeHistogram(now, dates) {
+		labels = append(labels, bucket.Label)
+		got[bucket.Label] = bucket.Modules
+	}
+
+	if want := []string{AgeUnder6Months, Age6To12Months, AgeOver1Year, AgeUnknown}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("AgeHistogram() labels = %v, want %v", labels, want)
+	}
+	want := map[string][]Module{
+		AgeUnder6Months: {fresh},
+		Age6To12Months:  {aging},
+		AgeOver1Year:    {stale},
+		AgeUnknown:      {tagged},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AgeHistogram() = %v, want %v", got, want)
+	}
+}
+
+func TestModule_VersionDate(t *testing.T) {
+	module := Module{Path: "github.com/dep", Version: "v1.0.0"}
+	pathDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	versionDate := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	dates := map[string]time.Time{"github.com/dep": pathDate, "github.com/dep@v1.0.0": versionDate}
+	if date, ok := module.VersionDate(dates); !ok || !date.Equal(versionDate) {
+		t.Errorf("VersionDate() = %v, %v, want the path@version entry %v", date, ok, versionDate)
+	}
+	if _, ok := module.VersionDate(nil); ok {
+		t.Error("VersionDate() should not find a date for a tagged version without metadata")
+	}
+}
@@ -0,0 +1,553 @@
+package tangled
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// OutdatedHeuristic returns modules that are likely upgrade candidates, sorted.
+// A module is flagged when its version is pre-1.0 (v0.*) or carries the
+// +incompatible suffix. This is purely a heuristic based on version strings;
+// no network lookups are made to check for newer releases.
+func (dg *DependencyGraph) OutdatedHeuristic() []Module {
+	var outdated []Module
+	for _, module := range dg.GetAllModules() {
+		if strings.HasPrefix(module.Version, "v0.") || strings.HasSuffix(module.Version, "+incompatible") {
+			outdated = append(outdated, module)
+		}
+	}
+	return outdated
+}
+
+// GraphStats summarizes the size and shape of a dependency graph
+type GraphStats struct {
+	Modules  int
+	Edges    int
+	MaxDepth int
+}
+
+// Stats returns module, edge and depth counts for the graph
+func (dg *DependencyGraph) Stats() GraphStats {
+	maxDepth := 0
+	for _, depth := range dg.DepthMap() {
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+
+	return GraphStats{
+		Modules:  len(dg.GetAllModules()),
+		Edges:    len(dg.Dependencies),
+		MaxDepth: maxDepth,
+	}
+}
+
+// SelfLoops returns the edges from a module path to itself, whether at the
+// same version or, typically through a replace directive, at another, in
+// input order
+func (dg *DependencyGraph) SelfLoops() []Dependency {
+	var loops []Dependency
+	for _, dep := range dg.Dependencies {
+		if dep.IsSelfLoop() {
+			loops = append(loops, dep)
+		}
+	}
+	return loops
+}
+
+// EdgesByDepth counts the edges whose source module is at each shortest
+// distance from the main module. Edges from unreachable modules are omitted.
+func (dg *DependencyGraph) EdgesByDepth() map[int]int {
+	depths := dg.DepthMap()
+	counts := make(map[int]int)
+	for _, dep := range dg.Dependencies {
+		if depth, ok := depths[dep.From.String()]; ok {
+			counts[depth]++
+		}
+	}
+	return counts
+}
+
+// BackEdges returns the edges that point back towards the main module, whose
+// target is at a smaller shortest distance from it than their source, in
+// input order. Every cycle reachable from the main module contains one.
+// Edges touching unreachable modules are omitted.
+func (dg *DependencyGraph) BackEdges() []Dependency {
+	depths := dg.DepthMap()
+	var back []Dependency
+	for _, dep := range dg.Dependencies {
+		from, fromOK := depths[dep.From.String()]
+		to, toOK := depths[dep.To.String()]
+		if fromOK && toOK && to < from {
+			back = append(back, dep)
+		}
+	}
+	return back
+}
+
+// TreeBalance describes how evenly dependencies spread out below the main
+// module
+type TreeBalance struct {
+	// BranchingFactor is the average number of distinct direct dependencies
+	// of the reachable modules that have any
+	BranchingFactor float64
+
+	// DepthCounts holds the number of modules at each shortest distance from
+	// the main module, starting with the main module itself at depth 0
+	DepthCounts []int
+
+	// Skew measures how lopsided the subtrees under the main module are, from
+	// 0 when its direct dependencies reach equally many modules, approaching
+	// 1 as one of them reaches far more than the rest. It is 0 with fewer
+	// than two direct dependencies, as there is nothing to compare.
+	Skew float64
+}
+
+// TreeBalance returns the branching factor, depth distribution and skew of
+// the tree below the main module. Subtree sizes count every module reachable
+// from a direct dependency, including itself, so shared modules count towards
+// each subtree that reaches them.
+func (dg *DependencyGraph) TreeBalance() TreeBalance {
+	tree := dg.GetTree()
+	depths := dg.DepthMap()
+
+	var balance TreeBalance
+	parents, edges := 0, 0
+	for module, depth := range depths {
+		for len(balance.DepthCounts) <= depth {
+			balance.DepthCounts = append(balance.DepthCounts, 0)
+		}
+		balance.DepthCounts[depth]++
+
+		if children := countDistinct(tree[module]); children > 0 {
+			parents++
+			edges += children
+		}
+	}
+	if parents > 0 {
+		balance.BranchingFactor = float64(edges) / float64(parents)
+	}
+
+	mainStr := dg.MainModule.String()
+	seen := make(map[string]bool)
+	largest, total, subtrees := 0, 0, 0
+	for _, child := range tree[mainStr] {
+		if seen[child] || child == mainStr {
+			continue
+		}
+		seen[child] = true
+		size := len(reachableIn(tree, child, map[string]bool{mainStr: true}))
+		largest = max(largest, size)
+		total += size
+		subtrees++
+	}
+	if subtrees > 1 {
+		share, even := float64(largest)/float64(total), 1/float64(subtrees)
+		balance.Skew = (share - even) / (1 - even)
+	}
+
+	return balance
+}
+
+// DetectCycles returns the cycles found by a depth-first traversal of the graph.
+// Each back edge yields one cycle, listed from the first repeated module onwards.
+// Traversal order is sorted so results are deterministic.
+func (dg *DependencyGraph) DetectCycles() [][]Module {
+	tree := dg.GetTree()
+	modules := make(map[string]Module)
+	for _, module := range dg.GetAllModules() {
+		modules[module.String()] = module
+	}
+
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	var cycles [][]Module
+
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = inProgress
+		stack = append(stack, node)
+
+		children := append([]string(nil), tree[node]...)
+		sort.Strings(children)
+		for _, child := range children {
+			switch state[child] {
+			case unvisited:
+				visit(child)
+			case inProgress:
+				// Back edge: the cycle is the stack from child to the current node
+				start := len(stack) - 1
+				for stack[start] != child {
+					start--
+				}
+				var cycle []Module
+				for _, key := range stack[start:] {
+					cycle = append(cycle, modules[key])
+				}
+				cycles = append(cycles, cycle)
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[node] = done
+	}
+
+	for _, module := range dg.GetAllModules() {
+		if state[module.String()] == unvisited {
+			visit(module.String())
+		}
+	}
+
+	return cycles
+}
+
+// CanonicalCycles returns the cycles found by DetectCycles in a form that does
+// not depend on traversal order: each cycle is rotated to start at its
+// smallest module string, and cycles are sorted by their module strings.
+func (dg *DependencyGraph) CanonicalCycles() [][]Module {
+	cycles := dg.DetectCycles()
+	for i, cycle := range cycles {
+		start := 0
+		for j, module := range cycle {
+			if module.String() < cycle[start].String() {
+				start = j
+			}
+		}
+		cycles[i] = slices.Concat(cycle[start:], cycle[:start])
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return slices.Compare(moduleKeys(cycles[i]), moduleKeys(cycles[j])) < 0
+	})
+	return cycles
+}
+
+// StronglyConnectedComponents returns the strongly connected components of
+// the graph: maximal sets of modules that can all reach each other. Modules
+// within a component are sorted, and components are ordered by their first
+// module. Every module belongs to exactly one component, so modules outside
+// any cycle form components of their own.
+func (dg *DependencyGraph) StronglyConnectedComponents() [][]Module {
+	tree := dg.GetTree()
+	modules := dg.GetAllModules()
+	byKey := make(map[string]Module, len(modules))
+	for _, module := range modules {
+		byKey[module.String()] = module
+	}
+
+	// Tarjan's algorithm
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]Module
+
+	var connect func(node string)
+	connect = func(node string) {
+		index[node] = len(index)
+		lowLink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, child := range tree[node] {
+			if _, seen := index[child]; !seen {
+				connect(child)
+				lowLink[node] = min(lowLink[node], lowLink[child])
+			} else if onStack[child] {
+				lowLink[node] = min(lowLink[node], index[child])
+			}
+		}
+
+		if lowLink[node] == index[node] {
+			var component []Module
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, byKey[top])
+				if top == node {
+					break
+				}
+			}
+			sort.Slice(component, func(i, j int) bool {
+				return component[i].String() < component[j].String()
+			})
+			components = append(components, component)
+		}
+	}
+
+	for _, module := range modules {
+		if _, seen := index[module.String()]; !seen {
+			connect(module.String())
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0].String() < components[j][0].String()
+	})
+	return components
+}
+
+// FeedbackEdges returns a set of edges whose removal leaves the graph
+// acyclic, in input order with duplicates listed once. Finding the smallest
+// such set is NP-hard, so this is a heuristic: within each strongly connected
+// component modules are ordered with the greedy method of Eades, Lin and
+// Smyth, and the edges pointing backwards in that order are reported. The
+// result is always sufficient but not necessarily minimal.
+func (dg *DependencyGraph) FeedbackEdges() []Dependency {
+	position := make(map[string]int)
+	component := make(map[string]int)
+	for i, scc := range dg.StronglyConnectedComponents() {
+		keys := make([]string, len(scc))
+		for j, module := range scc {
+			keys[j] = module.String()
+			component[keys[j]] = i
+		}
+		if len(keys) == 1 {
+			continue // only a self-loop can be a cycle, and it is always reported
+		}
+		for j, key := range dg.greedyOrder(keys) {
+			position[key] = j
+		}
+	}
+
+	var feedback []Dependency
+	seen := make(map[string]bool)
+	for _, dep := range dg.Dependencies {
+		from, to := dep.From.String(), dep.To.String()
+		if component[from] != component[to] || position[from] < position[to] || seen[edgeKey(dep)] {
+			continue
+		}
+		seen[edgeKey(dep)] = true
+		feedback = append(feedback, dep)
+	}
+	return feedback
+}
+
+// greedyOrder orders the given modules so that few edges among them point
+// backwards: sinks are moved to the end, sources to the front, and otherwise
+// the module with the largest surplus of outgoing over incoming edges goes
+// next. Ties are broken by module string.
+func (dg *DependencyGraph) greedyOrder(keys []string) []string {
+	remaining := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		remaining[key] = true
+	}
+	out := make(map[string][]string)
+	in := make(map[string][]string)
+	for _, dep := range dg.Dependencies {
+		from, to := dep.From.String(), dep.To.String()
+		if remaining[from] && remaining[to] && from != to {
+			out[from] = append(out[from], to)
+			in[to] = append(in[to], from)
+		}
+	}
+	degree := func(edges []string) int {
+		n := 0
+		for _, key := range edges {
+			if remaining[key] {
+				n++
+			}
+		}
+		return n
+	}
+
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	var front, back []string
+	for len(remaining) > 0 {
+		changed := true
+		for changed {
+			changed = false
+			for _, key := range sorted {
+				if !remaining[key] {
+					continue
+				}
+				switch {
+				case degree(out[key]) == 0:
+					back = append([]string{key}, back...)
+				case degree(in[key]) == 0:
+					front = append(front, key)
+				default:
+					continue
+				}
+				delete(remaining, key)
+				changed = true
+			}
+		}
+		if len(remaining) == 0 {
+			break
+		}
+
+		best, bestDelta := "", 0
+		for _, key := range sorted {
+			if !remaining[key] {
+				continue
+			}
+			if delta := degree(out[key]) - degree(in[key]); best == "" || delta > bestDelta {
+				best, bestDelta = key, delta
+			}
+		}
+		front = append(front, best)
+		delete(remaining, best)
+	}
+
+	return append(front, back...)
+}
+
+// ShortestPath returns the modules on a shortest dependency chain from one
+// module to another, including both ends, or nil if to is not reachable.
+// Ties are broken by input order, so the result is deterministic.
+func (dg *DependencyGraph) ShortestPath(from, to Module) []Module {
+	start, goal := from.String(), to.String()
+	modules := make(map[string]Module)
+	for _, module := range dg.GetAllModules() {
+		modules[module.String()] = module
+	}
+	if _, ok := modules[start]; !ok {
+		return nil
+	}
+
+	tree := dg.GetTree()
+	parent := make(map[string]string)
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 && !seen[goal] {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, child := range tree[current] {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			parent[child] = current
+			queue = append(queue, child)
+		}
+	}
+	if !seen[goal] {
+		return nil
+	}
+
+	var path []Module
+	for current := goal; current != start; current = parent[current] {
+		path = append(path, modules[current])
+	}
+	path = append(path, from)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// DegreeMaps returns the number of incoming and outgoing edges per module,
+// keyed by module string. Every module in the graph has an entry in both maps.
+func (dg *DependencyGraph) DegreeMaps() (inDegree, outDegree map[string]int) {
+	inDegree = make(map[string]int)
+	outDegree = make(map[string]int)
+
+	for _, module := range dg.GetAllModules() {
+		inDegree[module.String()] = 0
+		outDegree[module.String()] = 0
+	}
+	for _, dep := range dg.Dependencies {
+		outDegree[dep.From.String()]++
+		inDegree[dep.To.String()]++
+	}
+
+	return inDegree, outDegree
+}
+
+// DegreeCentrality returns the number of edges per module counted in the
+// given mode: incoming, outgoing, or both. An empty mode means CentralityIn.
+func (dg *DependencyGraph) DegreeCentrality(mode CentralityMode) map[string]int {
+	inDegree, outDegree := dg.DegreeMaps()
+	switch mode {
+	case CentralityOut:
+		return outDegree
+	case CentralityBoth:
+		for key, n := range outDegree {
+			inDegree[key] += n
+		}
+		return inDegree
+	default:
+		return inDegree
+	}
+}
+
+// edgeKey identifies an edge by its endpoints, ignoring any collapsed path
+func edgeKey(dep Dependency) string {
+	return dep.From.String() + " " + dep.To.String()
+}
+
+// EdgeCounts returns how many times each edge appears in the graph, keyed by
+// "from to" module strings
+func (dg *DependencyGraph) EdgeCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, dep := range dg.Dependencies {
+		counts[edgeKey(dep)]++
+	}
+	return counts
+}
+
+// BlastRadius returns, for every module, how many other modules transitively
+// depend on it
+func (dg *DependencyGraph) BlastRadius() map[string]int {
+	return dg.BlastRadiusParallel(1)
+}
+
+// BlastRadiusParallel computes BlastRadius using up to workers goroutines
+func (dg *DependencyGraph) BlastRadiusParallel(workers int) map[string]int {
+	reverse := make(map[string][]string)
+	for _, dep := range dg.Dependencies {
+		toStr := dep.To.String()
+		reverse[toStr] = append(reverse[toStr], dep.From.String())
+	}
+
+	return mapModules(moduleKeys(dg.GetAllModules()), workers, func(start string) int {
+		return len(reachableIn(reverse, start, nil)) - 1
+	})
+}
+
+// TransitiveClosure returns, for every module, the sorted module strings it
+// transitively depends on, excluding itself
+func (dg *DependencyGraph) TransitiveClosure() map[string][]string {
+	return dg.TransitiveClosureParallel(1)
+}
+
+// TransitiveClosureParallel computes TransitiveClosure using up to workers goroutines
+func (dg *DependencyGraph) TransitiveClosureParallel(workers int) map[string][]string {
+	tree := dg.GetTree()
+	modules := moduleKeys(dg.GetAllModules())
+
+	return mapModules(modules, workers, func(start string) []string {
+		seen := reachableIn(tree, start, nil)
+		deps := make([]string, 0, len(seen)-1)
+		for _, module := range modules {
+			if module != start && seen[module] {
+				deps = append(deps, module)
+			}
+		}
+		return deps
+	})
+}
+
+// EdgeWeights returns, for each edge, how many modules are reached by
+// traversing it: its target plus everything the target transitively depends
+// on. Edges are keyed by "from to" module strings, as in EdgeCounts.
+func (dg *DependencyGraph) EdgeWeights() map[string]int {
+	closure := dg.TransitiveClosure()
+
+	weights := make(map[string]int, len(dg.Dependencies))
+	for _, dep := range dg.Dependencies {
+		weights[edgeKey(dep)] = 1 + len(closure[dep.To.String()])
+	}
+	return weights
+}
@@ -0,0 +1,314 @@
+package tangled
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDependencyGraph_OutdatedHeuristic(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	graph := NewDependencyGraph(mainModule)
+
+	preRelease := Module{Path: "github.com/pre", Version: "v0.3.0"}
+	incompatible := Module{Path: "github.com/old", Version: "v3.2.1+incompatible"}
+	stable := Module{Path: "github.com/stable", Version: "v2.1.0"}
+
+	graph.AddDependency(mainModule, preRelease)
+	graph.AddDependency(mainModule, incompatible)
+	graph.AddDependency(mainModule, stable)
+
+	flagged := make(map[Module]bool)
+	for _, module := range graph.OutdatedHeuristic() {
+		flagged[module] = true
+	}
+
+	if !flagged[preRelease] {
+		t.Errorf("expected %v to be flagged", preRelease)
+	}
+	if !flagged[incompatible] {
+		t.Errorf("expected %v to be flagged", incompatible)
+	}
+	if flagged[stable] {
+		t.Errorf("expected %v not to be flagged", stable)
+	}
+	if flagged[mainModule] {
+		t.Errorf("expected main module not to be flagged")
+	}
+}
+
+func TestDependencyGraph_Stats(t *testing.T) {
+	graph := createTestGraph()
+
+	stats := graph.Stats()
+	if stats.Modules != 4 {
+		t.Errorf("Stats().Modules = %d, want 4", stats.Modules)
+	}
+	if stats.Edges != 3 {
+		t.Errorf("Stats().Edges = %d, want 3", stats.Edges)
+	}
+	if stats.MaxDepth != 2 {
+		t.Errorf("Stats().MaxDepth = %d, want 2", stats.MaxDepth)
+	}
+}
+
+func TestDependencyGraph_SelfLoops(t *testing.T) {
+	mainModule := Module{Path: "example.com/main"}
+	foo1 := Module{Path: "example.com/foo", Version: "v1.0.0"}
+	foo2 := Module{Path: "example.com/foo", Version: "v2.0.0"}
+	bar := Module{Path: "example.com/bar", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, foo1)
+	graph.AddDependency(foo1, foo2)
+	graph.AddDependency(foo1, bar)
+
+	loops := graph.SelfLoops()
+	if len(loops) != 1 || loops[0].From != foo1 || loops[0].To != foo2 {
+		t.Errorf("SelfLoops() = %v, want only foo@v1 -> foo@v2", loops)
+	}
+}
+
+func TestDependencyGraph_EdgesByDepth(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(Module{Path: "github.com/dep2", Version: "v2.0.0"}, Module{Path: "github.com/subdep", Version: "v1.0.0"})
+	graph.AddDependency(Module{Path: "github.com/orphan"}, Module{Path: "github.com/dep1", Version: "v1.0.0"})
+
+	want := map[int]int{0: 2, 1: 2}
+	if got := graph.EdgesByDepth(); !reflect.DeepEqual(got, want) {
+		t.Errorf("EdgesByDepth() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyGraph_BackEdges(t *testing.T) {
+	graph := createTestGraph()
+	subdep := Module{Path: "github.com/subdep", Version: "v1.0.0"}
+	dep1 := Module{Path: "github.com/dep1", Version: "v1.0.0"}
+	dep2 := Module{Path: "github.com/dep2", Version: "v2.0.0"}
+	graph.AddDependency(subdep, dep1)
+	graph.AddDependency(dep1, dep2)
+	graph.AddDependency(Module{Path: "github.com/orphan"}, dep1)
+
+	want := []Dependency{{From: subdep, To: dep1}}
+	if got := graph.BackEdges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("BackEdges() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyGraph_TreeBalance(t *testing.T) {
+	mainModule := Module{Path: "example.com/main"}
+	module := func(name string) Module { return Module{Path: "example.com/" + name, Version: "v1.0.0"} }
+
+	even := NewDependencyGraph(mainModule)
+	lopsided := NewDependencyGraph(mainModule)
+	for _, name := range []string{"a", "b", "c"} {
+		even.AddDependency(mainModule, module(name))
+		even.AddDependency(module(name), module(name+"1"))
+		lopsided.AddDependency(mainModule, module(name))
+		lopsided.AddDependency(module("a"), module(name+"1"))
+	}
+
+	evenBalance := even.TreeBalance()
+	if evenBalance.Skew != 0 {
+		t.Errorf("even fan-out Skew = %v, want 0", evenBalance.Skew)
+	}
+	if want := []int{1, 3, 3}; !reflect.DeepEqual(evenBalance.DepthCounts, want) {
+		t.Errorf("DepthCounts = %v, want %v", evenBalance.DepthCounts, want)
+	}
+	if evenBalance.BranchingFactor != 1.5 {
+		t.Errorf("BranchingFactor = %v, want 1.5 (6 edges from 4 parents)", evenBalance.BranchingFactor)
+	}
+
+	if skew := lopsided.TreeBalance().Skew; skew < 0.4 {
+		t.Errorf("all deps under one child Skew = %v, want high skew", skew)
+	}
+}
+
+func TestDependencyGraph_DetectCycles(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+
+	t.Run("acyclic", func(t *testing.T) {
+		graph := createTestGraph()
+		if cycles := graph.DetectCycles(); len(cycles) != 0 {
+			t.Errorf("DetectCycles() = %v, want none", cycles)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		graph := NewDependencyGraph(a)
+		graph.AddDependency(a, b)
+		graph.AddDependency(b, c)
+		graph.AddDependency(c, b)
+
+		cycles := graph.DetectCycles()
+		if len(cycles) != 1 {
+			t.Fatalf("DetectCycles() returned %d cycles, want 1", len(cycles))
+		}
+		if len(cycles[0]) != 2 || cycles[0][0] != b || cycles[0][1] != c {
+			t.Errorf("DetectCycles()[0] = %v, want [%v %v]", cycles[0], b, c)
+		}
+	})
+}
+
+func TestDependencyGraph_CanonicalCycles(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, c)
+	graph.AddDependency(c, b)
+	graph.AddDependency(b, c)
+	graph.AddDependency(c, a)
+
+	want := [][]Module{{a, c}, {b, c}}
+	if got := graph.CanonicalCycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CanonicalCycles() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyGraph_DegreeMaps(t *testing.T) {
+	graph := createTestGraph()
+
+	inDegree, outDegree := graph.DegreeMaps()
+	if outDegree["github.com/example/main"] != 2 {
+		t.Errorf("main out-degree = %d, want 2", outDegree["github.com/example/main"])
+	}
+	if inDegree["github.com/example/main"] != 0 {
+		t.Errorf("main in-degree = %d, want 0", inDegree["github.com/example/main"])
+	}
+	if inDegree["github.com/subdep@v1.0.0"] != 1 || outDegree["github.com/subdep@v1.0.0"] != 0 {
+		t.Errorf("subdep degrees = %d/%d, want 1/0", inDegree["github.com/subdep@v1.0.0"], outDegree["github.com/subdep@v1.0.0"])
+	}
+}
+
+func TestDependencyGraph_EdgeCounts(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(graph.MainModule, Module{Path: "github.com/dep1", Version: "v1.0.0"})
+
+	counts := graph.EdgeCounts()
+	if n := counts["github.com/example/main github.com/dep1@v1.0.0"]; n != 2 {
+		t.Errorf("duplicated edge count = %d, want 2", n)
+	}
+	if n := counts["github.com/example/main github.com/dep2@v2.0.0"]; n != 1 {
+		t.Errorf("single edge count = %d, want 1", n)
+	}
+}
+
+func TestDependencyGraph_BlastRadius(t *testing.T) {
+	graph := createTestGraph()
+
+	radius := graph.BlastRadius()
+	if radius["github.com/subdep@v1.0.0"] != 2 {
+		t.Errorf("subdep blast radius = %d, want 2", radius["github.com/subdep@v1.0.0"])
+	}
+	if radius["github.com/example/main"] != 0 {
+		t.Errorf("main blast radius = %d, want 0", radius["github.com/example/main"])
+	}
+	if radius["github.com/subdep@v1.0.0"] <= radius["github.com/dep2@v2.0.0"] {
+		t.Errorf("widely depended module should have a larger blast radius than a leaf, got %v", radius)
+	}
+}
+
+func TestDependencyGraph_TransitiveClosure(t *testing.T) {
+	graph := createTestGraph()
+
+	closure := graph.TransitiveClosure()
+	if got := closure["github.com/example/main"]; len(got) != 3 {
+		t.Errorf("main closure = %v, want all three dependencies", got)
+	}
+	if got := closure["github.com/dep1@v1.0.0"]; len(got) != 1 || got[0] != "github.com/subdep@v1.0.0" {
+		t.Errorf("dep1 closure = %v, want [github.com/subdep@v1.0.0]", got)
+	}
+	if got := closure["github.com/subdep@v1.0.0"]; len(got) != 0 {
+		t.Errorf("leaf closure = %v, want empty", got)
+	}
+}
+
+func TestDependencyGraph_EdgeWeights(t *testing.T) {
+	graph := createTestGraph()
+
+	weights := graph.EdgeWeights()
+	if w := weights["github.com/example/main github.com/dep1@v1.0.0"]; w != 2 {
+		t.Errorf("main -> dep1 weight = %d, want 2", w)
+	}
+	if w := weights["github.com/example/main github.com/dep2@v2.0.0"]; w != 1 {
+		t.Errorf("main -> dep2 weight = %d, want 1", w)
+	}
+}
+
+func TestDependencyGraph_ShortestPath(t *testing.T) {
+	graph := createTestGraph()
+	dep2 := Module{Path: "github.com/dep2", Version: "v2.0.0"}
+	subdep := Module{Path: "github.com/subdep", Version: "v1.0.0"}
+	graph.AddDependency(dep2, Module{Path: "github.com/extra", Version: "v1.0.0"})
+	graph.AddDependency(Module{Path: "github.com/extra", Version: "v1.0.0"}, subdep)
+
+	path := graph.ShortestPath(graph.MainModule, subdep)
+	var got []string
+	for _, module := range path {
+		got = append(got, module.String())
+	}
+	want := "github.com/example/main github.com/dep1@v1.0.0 github.com/subdep@v1.0.0"
+	if strings.Join(got, " ") != want {
+		t.Errorf("ShortestPath() = %v, want %s", got, want)
+	}
+
+	if path := graph.ShortestPath(subdep, graph.MainModule); path != nil {
+		t.Errorf("ShortestPath() = %v, want nil for an unreachable module", path)
+	}
+}
+
+func TestDependencyGraph_StronglyConnectedComponents(t *testing.T) {
+	graph := createTestGraph()
+	a := Module{Path: "github.com/a", Version: "v1.0.0"}
+	b := Module{Path: "github.com/b", Version: "v1.0.0"}
+	graph.AddDependency(graph.MainModule, a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, a)
+
+	components := graph.StronglyConnectedComponents()
+	if len(components) != 5 {
+		t.Fatalf("components = %v, want 5", components)
+	}
+	if first := components[0]; len(first) != 2 || first[0] != a || first[1] != b {
+		t.Errorf("components[0] = %v, want [a b]", first)
+	}
+}
+
+func TestDependencyGraph_FeedbackEdges(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	graph := NewDependencyGraph(mainModule)
+	a := Module{Path: "github.com/a", Version: "v1.0.0"}
+	b := Module{Path: "github.com/b", Version: "v1.0.0"}
+	c := Module{Path: "github.com/c", Version: "v1.0.0"}
+	d := Module{Path: "github.com/d", Version: "v1.0.0"}
+	graph.AddDependency(mainModule, a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, c)
+	graph.AddDependency(c, a)
+	graph.AddDependency(c, b)
+	graph.AddDependency(b, d)
+	graph.AddDependency(d, d)
+	graph.AddDependency(d, mainModule)
+
+	feedback := graph.FeedbackEdges()
+	if len(feedback) == 0 || len(feedback) >= len(graph.Dependencies) {
+		t.Fatalf("FeedbackEdges() = %v, want a proper subset of the edges", feedback)
+	}
+
+	cut := make(map[string]bool)
+	for _, dep := range feedback {
+		cut[edgeKey(dep)] = true
+	}
+	acyclic := graph.subgraph(func(dep Dependency) bool { return !cut[edgeKey(dep)] })
+	if cycles := acyclic.DetectCycles(); len(cycles) != 0 {
+		t.Errorf("cycles remain after removing %v: %v", feedback, cycles)
+	}
+
+	if edges := createTestGraph().FeedbackEdges(); len(edges) != 0 {
+		t.Errorf("FeedbackEdges() = %v, want none for an acyclic graph", edges)
+	}
+}
@@ -0,0 +1,58 @@
+package tangled
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// VersionMismatch records a module whose selected version differs from a baseline
+type VersionMismatch struct {
+	Path     string
+	Expected string
+	Actual   string // empty when the module is missing from the graph
+}
+
+// String returns a one-line description of the mismatch
+func (vm VersionMismatch) String() string {
+	if vm.Actual == "" {
+		return fmt.Sprintf("%s: expected %s, missing from graph", vm.Path, vm.Expected)
+	}
+	return fmt.Sprintf("%s: expected %s, got %s", vm.Path, vm.Expected, vm.Actual)
+}
+
+// ParseBaselineFromFile reads a baseline file and returns expected versions keyed by module path
+func ParseBaselineFromFile(filename string) (map[string]string, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseBaseline(file)
+}
+
+// ParseBaseline reads "module-path version" lines and returns expected
+// versions keyed by module path. Blank lines and lines starting with # are ignored.
+func ParseBaseline(reader io.Reader) (map[string]string, error) {
+	return parsePairs(reader)
+}
+
+// CompareBaseline returns every baseline module whose selected version in the
+// graph differs from the expected version, sorted by path
+func (dg *DependencyGraph) CompareBaseline(baseline map[string]string) []VersionMismatch {
+	selected := dg.SelectedVersions()
+
+	var mismatches []VersionMismatch
+	for path, expected := range baseline {
+		if actual := selected[path]; actual != expected {
+			mismatches = append(mismatches, VersionMismatch{Path: path, Expected: expected, Actual: actual})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Path < mismatches[j].Path
+	})
+	return mismatches
+}
@@ -0,0 +1,40 @@
+package tangled
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDependencyGraph_CompareBaseline(t *testing.T) {
+	graph := createTestGraph()
+
+	baseline, err := ParseBaseline(strings.NewReader(`# golden versions
+github.com/dep1 v1.0.0
+github.com/dep2 v2.1.0
+github.com/subdep v1.0.0`))
+	if err != nil {
+		t.Fatalf("ParseBaseline() error = %v", err)
+	}
+
+	mismatches := graph.CompareBaseline(baseline)
+	if len(mismatches) != 1 {
+		t.Fatalf("CompareBaseline() returned %d mismatches, want 1: %v", len(mismatches), mismatches)
+	}
+
+	want := VersionMismatch{Path: "github.com/dep2", Expected: "v2.1.0", Actual: "v2.0.0"}
+	if mismatches[0] != want {
+		t.Errorf("CompareBaseline()[0] = %+v, want %+v", mismatches[0], want)
+	}
+	if mismatches[0].String() != "github.com/dep2: expected v2.1.0, got v2.0.0" {
+		t.Errorf("VersionMismatch.String() = %q", mismatches[0].String())
+	}
+}
+
+func TestDependencyGraph_CompareBaselineMissing(t *testing.T) {
+	graph := createTestGraph()
+
+	mismatches := graph.CompareBaseline(map[string]string{"github.com/gone": "v1.0.0"})
+	if len(mismatches) != 1 || mismatches[0].Actual != "" {
+		t.Errorf("CompareBaseline() = %v, want one missing module", mismatches)
+	}
+}
@@ -0,0 +1,198 @@
+package tangled
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ParseBazelGraphFromFile parses a Bazel dependency listing file and returns a DependencyGraph
+func ParseBazelGraphFromFile(filename string) (*DependencyGraph, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line argument
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseBazelGraph(file)
+}
+
+// ParseBazelGraph parses Bazel-style dependency output. Two forms are
+// understood and may be mixed:
+//
+//   - edge lines of the form `from -> to`, optionally quoted and ending in
+//     ";" as in `bazel query --output graph`
+//   - Gazelle go_repository rules giving the importpath and version of each
+//     external repository
+//
+// Edge endpoints are module strings, external labels such as
+// `@org_golang_x_text//:go_default_library`, which resolve through the
+// go_repository rules to their importpath and version, or workspace labels
+// starting with "//", which resolve to the main module. The main module path
+// comes from a `# gazelle:prefix` directive when present, and is otherwise
+// identified from the edges as for go mod graph output. When the input holds
+// no edge lines, every go_repository becomes a direct dependency of the main
+// module. Other lines are ignored.
+func ParseBazelGraph(reader io.Reader) (*DependencyGraph, error) {
+	input, err := scanBazel(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var mainModule Module
+	if input.prefix != "" {
+		mainModule = Module{Path: input.prefix}
+	}
+
+	var dependencies []Dependency
+	if len(input.edges) == 0 {
+		if input.prefix == "" {
+			return nil, fmt.Errorf("no dependencies found in input")
+		}
+		for _, repo := range input.repos {
+			dependencies = append(dependencies, Dependency{From: mainModule, To: repo.module, SourceLine: repo.line})
+		}
+	}
+	for _, edge := range input.edges {
+		from, err := input.resolve(edge.from, mainModule)
+		if err != nil {
+			return nil, ParseError{Line: edge.line, Content: edge.content, Err: err}
+		}
+		to, err := input.resolve(edge.to, mainModule)
+		if err != nil {
+			return nil, ParseError{Line: edge.line, Content: edge.content, Err: err}
+		}
+		dependencies = append(dependencies, Dependency{From: from, To: to, SourceLine: edge.line})
+	}
+
+	if len(dependencies) == 0 {
+		return nil, fmt.Errorf("no dependencies found in input")
+	}
+	if input.prefix == "" {
+		mainModule = identifyMainModule(dependencies)
+	}
+
+	graph := NewDependencyGraph(mainModule)
+	for _, dep := range dependencies {
+		graph.addEdge(dep)
+	}
+	return graph, nil
+}
+
+// bazelInput holds the raw contents of a Bazel dependency listing
+type bazelInput struct {
+	prefix string
+	edges  []bazelEdge
+	repos  []bazelRepo
+	byName map[string]Module
+}
+
+// bazelEdge is an unresolved `from -> to` line
+type bazelEdge struct {
+	from, to string
+	line     int
+	content  string
+}
+
+// bazelRepo is a go_repository rule
+type bazelRepo struct {
+	module Module
+	line   int
+}
+
+// scanBazel collects edge lines, go_repository rules and the gazelle prefix
+func scanBazel(reader io.Reader) (*bazelInput, error) {
+	scanner := bufio.NewScanner(reader)
+	input := &bazelInput{byName: make(map[string]Module)}
+	lineNum := 0
+
+	var rule map[string]string // attributes of the go_repository being read
+	ruleLine := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if prefix, ok := strings.CutPrefix(line, "# gazelle:prefix"); ok {
+			input.prefix = strings.TrimSpace(prefix)
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if rule != nil {
+			if strings.HasPrefix(line, ")") {
+				if err := input.addRepo(rule, ruleLine); err != nil {
+					return nil, err
+				}
+				rule = nil
+				continue
+			}
+			if key, value, ok := strings.Cut(line, "="); ok {
+				rule[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `",`)
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "go_repository(") {
+			rule = make(map[string]string)
+			ruleLine = lineNum
+			continue
+		}
+
+		from, to, ok := strings.Cut(line, "->")
+		if !ok {
+			continue
+		}
+		from = strings.Trim(strings.TrimSpace(from), `"`)
+		to = strings.Trim(strings.TrimSuffix(strings.TrimSpace(to), ";"), `"`)
+		if from == "" || to == "" {
+			return nil, ParseError{Line: lineNum, Content: line, Err: fmt.Errorf("expected \"from -> to\"")}
+		}
+		input.edges = append(input.edges, bazelEdge{from: from, to: to, line: lineNum, content: line})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+	if rule != nil {
+		return nil, fmt.Errorf("unterminated go_repository rule starting at line %d", ruleLine)
+	}
+
+	return input, nil
+}
+
+// addRepo records a go_repository rule from its attributes
+func (b *bazelInput) addRepo(attrs map[string]string, line int) error {
+	name, importPath := attrs["name"], attrs["importpath"]
+	if name == "" || importPath == "" {
+		return ParseError{Line: line, Content: "go_repository(", Err: fmt.Errorf("go_repository requires name and importpath")}
+	}
+
+	module := Module{Path: importPath, Version: attrs["version"]}
+	b.byName[name] = module
+	b.repos = append(b.repos, bazelRepo{module: module, line: line})
+	return nil
+}
+
+// resolve maps an edge endpoint to a module
+func (b *bazelInput) resolve(endpoint string, mainModule Module) (Module, error) {
+	switch {
+	case strings.HasPrefix(endpoint, "//"):
+		if b.prefix == "" {
+			return Module{}, fmt.Errorf("workspace label %s requires a # gazelle:prefix directive", endpoint)
+		}
+		return mainModule, nil
+	case strings.HasPrefix(endpoint, "@"):
+		name, _, _ := strings.Cut(strings.TrimPrefix(endpoint, "@"), "//")
+		if module, ok := b.byName[name]; ok {
+			return module, nil
+		}
+		return Module{Path: name}, nil
+	default:
+		return parseModule(endpoint)
+	}
+}
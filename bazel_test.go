@@ -0,0 +1,80 @@
+package tangled
+
+import (
+	"strings"
+	"testing"
+)
+
+// edgeStrings returns every edge of the graph as "from to"
+func edgeStrings(graph *DependencyGraph) []string {
+	edges := make([]string, len(graph.Dependencies))
+	for i, dep := range graph.Dependencies {
+		edges[i] = edgeKey(dep)
+	}
+	return edges
+}
+
+func TestParseBazelGraph_Edges(t *testing.T) {
+	input := `# gazelle:prefix github.com/example/main
+go_repository(
+    name = "com_github_dep1",
+    importpath = "github.com/dep1",
+    version = "v1.0.0",
+    sum = "h1:abc=",
+)
+
+digraph mygraph {
+  "//cmd:main" -> "@com_github_dep1//:go_default_library";
+  "@com_github_dep1//:go_default_library" -> github.com/subdep@v1.0.0
+}
+`
+
+	graph, err := ParseBazelGraph(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseBazelGraph() error = %v", err)
+	}
+	if graph.MainModule.Path != "github.com/example/main" {
+		t.Errorf("MainModule = %v, want the gazelle prefix", graph.MainModule)
+	}
+
+	want := "github.com/example/main github.com/dep1@v1.0.0,github.com/dep1@v1.0.0 github.com/subdep@v1.0.0"
+	if got := strings.Join(edgeStrings(graph), ","); got != want {
+		t.Errorf("edges = %s, want %s", got, want)
+	}
+	if graph.Dependencies[0].SourceLine != 10 {
+		t.Errorf("SourceLine = %d, want 10", graph.Dependencies[0].SourceLine)
+	}
+}
+
+func TestParseBazelGraph_Repositories(t *testing.T) {
+	input := `# gazelle:prefix github.com/example/main
+go_repository(
+    name = "org_golang_x_text",
+    importpath = "golang.org/x/text",
+    version = "v0.3.0",
+)
+`
+
+	graph, err := ParseBazelGraph(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseBazelGraph() error = %v", err)
+	}
+	if got := strings.Join(edgeStrings(graph), ","); got != "github.com/example/main golang.org/x/text@v0.3.0" {
+		t.Errorf("edges = %s, want the repository as a direct dependency", got)
+	}
+}
+
+func TestParseBazelGraph_Errors(t *testing.T) {
+	invalid := []string{
+		"",
+		"//cmd:main -> github.com/dep1@v1.0.0",
+		"go_repository(\n    name = \"x\",\n",
+		"# gazelle:prefix github.com/example/main\ngo_repository(\n    name = \"x\",\n)\n",
+		"github.com/example/main -> ",
+	}
+	for _, input := range invalid {
+		if _, err := ParseBazelGraph(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseBazelGraph(%q) should fail", input)
+		}
+	}
+}
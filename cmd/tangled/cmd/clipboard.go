@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// copyToClipboard pipes data into the first clipboardCommands entry found on
+// the PATH, failing with a list of the tools tried when none is installed
+func copyToClipboard(data []byte) error {
+	tried := make([]string, len(clipboardCommands))
+	for i, args := range clipboardCommands {
+		tried[i] = args[0]
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+
+		copyCmd := exec.Command(path, args[1:]...) // #nosec G204 -- fixed per-platform clipboard tool
+		copyCmd.Stdin = bytes.NewReader(data)
+		if output, err := copyCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %w %s", args[0], err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	return fmt.Errorf("no clipboard tool found (tried %s)", strings.Join(tried, ", "))
+}
@@ -0,0 +1,6 @@
+//go:build darwin
+
+package cmd
+
+// clipboardCommands are the clipboard tools tried by copyToClipboard, in order
+var clipboardCommands = [][]string{{"pbcopy"}}
@@ -0,0 +1,11 @@
+//go:build !darwin && !windows
+
+package cmd
+
+// clipboardCommands are the clipboard tools tried by copyToClipboard, in
+// order: Wayland first, then the two common X11 tools
+var clipboardCommands = [][]string{
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
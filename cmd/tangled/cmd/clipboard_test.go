@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// stubClipboard replaces the clipboard tools with a shell command writing to
+// a file for the duration of the test, returning that file's path
+func stubClipboard(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub clipboard uses sh")
+	}
+
+	path := filepath.Join(t.TempDir(), "clipboard.txt")
+	saved := clipboardCommands
+	clipboardCommands = [][]string{{"sh", "-c", `cat > "$0"`, path}}
+	t.Cleanup(func() { clipboardCommands = saved })
+	return path
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	path := stubClipboard(t)
+
+	if err := copyToClipboard([]byte("copied\n")); err != nil {
+		t.Fatalf("copyToClipboard() error = %v", err)
+	}
+	got, err := os.ReadFile(path) // #nosec G304 -- test file in a temporary directory
+	if err != nil {
+		t.Fatalf("failed to read stub clipboard: %v", err)
+	}
+	if string(got) != "copied\n" {
+		t.Errorf("clipboard = %q, want %q", got, "copied\n")
+	}
+}
+
+func TestCopyToClipboard_NoTool(t *testing.T) {
+	saved := clipboardCommands
+	clipboardCommands = [][]string{{"tangled-no-such-clipboard-tool"}}
+	t.Cleanup(func() { clipboardCommands = saved })
+
+	err := copyToClipboard([]byte("copied\n"))
+	if err == nil || !strings.Contains(err.Error(), "no clipboard tool found (tried tangled-no-such-clipboard-tool)") {
+		t.Errorf("copyToClipboard() error = %v, want a missing tool error", err)
+	}
+}
+
+func TestClipboardFlag(t *testing.T) {
+	path := stubClipboard(t)
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--clipboard", "-f", "summary", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output != "" {
+		t.Errorf("stdout = %q, want nothing when copying to the clipboard", output)
+	}
+	got, err := os.ReadFile(path) // #nosec G304 -- test file in a temporary directory
+	if err != nil {
+		t.Fatalf("failed to read stub clipboard: %v", err)
+	}
+	if !strings.Contains(string(got), "github.com/example/main: 4 modules") {
+		t.Errorf("clipboard = %q, want the summary", got)
+	}
+
+	if _, err := executeRoot(t, "--clipboard", "-o", filepath.Join(t.TempDir(), "out.txt"), graphPath); err == nil {
+		t.Error("Execute() should reject --clipboard with -o")
+	}
+}
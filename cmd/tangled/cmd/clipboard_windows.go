@@ -0,0 +1,6 @@
+//go:build windows
+
+package cmd
+
+// clipboardCommands are the clipboard tools tried by copyToClipboard, in order
+var clipboardCommands = [][]string{{"clip.exe"}}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/scottbrown/tangled"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat    string
+	diffOutput    string
+	diffNewOnly   bool
+	diffDepthOnly bool
+)
+
+// diffCmd compares two graph files
+var diffCmd = &cobra.Command{
+	Use:   "diff [old-graph] [new-graph]",
+	Short: "Compare two dependency graphs",
+	Long: `diff lists the modules added (+) and removed (-) between two graph files.
+
+With --depth-only, it lists modules whose distance from the main module
+changed instead. With --new-only, it renders a graph of just the newly added modules
+and the edges leading to them, in any output format.
+
+Example usage:
+  tangled diff old.graph new.graph
+  tangled diff --depth-only old.graph new.graph
+  tangled diff --new-only -f dot old.graph new.graph`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	before, err := tangled.ParseGraphFromFile(args[0])
+	if err != nil {
+		return withExitCode(ExitParse, fmt.Errorf("failed to parse graph file: %w", err))
+	}
+	after, err := tangled.ParseGraphFromFile(args[1])
+	if err != nil {
+		return withExitCode(ExitParse, fmt.Errorf("failed to parse graph file: %w", err))
+	}
+
+	var renderer tangled.Renderer
+	if diffNewOnly {
+		if renderer, err = lookupFormat(diffFormat); err != nil {
+			return err
+		}
+		configureRenderer(renderer, tangled.RenderOptions{})
+	}
+
+	// Determine output destination
+	var writer io.Writer
+	if diffOutput == "" || diffOutput == "-" {
+		writer = cmd.OutOrStdout()
+	} else {
+		file, err := os.Create(diffOutput) // #nosec G304 -- CLI tool, output file from user-provided command line flag
+		if err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to create output file: %w", err))
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	if renderer != nil {
+		return renderGraph(renderer, after.AddedSince(before), writer, args[1])
+	}
+
+	diff := tangled.Diff(before, after)
+	if diffDepthOnly {
+		for _, change := range diff.DepthChanges {
+			if _, err := fmt.Fprintln(writer, change.String()); err != nil {
+				return withExitCode(ExitRender, fmt.Errorf("failed to write diff: %w", err))
+			}
+		}
+		return nil
+	}
+	for _, module := range diff.AddedModules {
+		if _, err := fmt.Fprintf(writer, "+ %s\n", module.String()); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write diff: %w", err))
+		}
+	}
+	for _, module := range diff.RemovedModules {
+		if _, err := fmt.Fprintf(writer, "- %s\n", module.String()); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write diff: %w", err))
+		}
+	}
+	return nil
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffNewOnly, "new-only", false, "Render only the newly added modules and the edges leading to them")
+	diffCmd.Flags().BoolVar(&diffDepthOnly, "depth-only", false, "List only modules whose depth from the main module changed")
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format for --new-only")
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "", "Output file (default: stdout)")
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const diffNewGraph = `github.com/example/main github.com/dep1@v1.0.0
+github.com/example/main github.com/dep3@v1.0.0
+github.com/dep1@v1.0.0 github.com/subdep@v1.0.0
+`
+
+func writeDiffGraphs(t *testing.T) (string, string) {
+	t.Helper()
+
+	oldPath := writeGraphFile(t, testGraph)
+	newPath := filepath.Join(t.TempDir(), "new.graph")
+	if err := os.WriteFile(newPath, []byte(diffNewGraph), 0o600); err != nil {
+		t.Fatalf("failed to write graph file: %v", err)
+	}
+	return oldPath, newPath
+}
+
+func TestDiff(t *testing.T) {
+	oldPath, newPath := writeDiffGraphs(t)
+
+	output, err := executeRoot(t, "diff", oldPath, newPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "+ github.com/dep3@v1.0.0\n- github.com/dep2@v2.0.0\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestDiffNewOnly(t *testing.T) {
+	oldPath, newPath := writeDiffGraphs(t)
+
+	output, err := executeRoot(t, "diff", "--new-only", "-f", "dot", oldPath, newPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, `"github_com_example_main" -> "github_com_dep3_v1_0_0";`) {
+		t.Errorf("output should contain the added module, got:\n%s", output)
+	}
+	for _, unchanged := range []string{"dep1", "subdep", "dep2"} {
+		if strings.Contains(output, unchanged) {
+			t.Errorf("output should not contain unchanged module %s, got:\n%s", unchanged, output)
+		}
+	}
+}
+
+func TestDiffDepthOnly(t *testing.T) {
+	oldPath, newPath := writeDiffGraphs(t)
+	if err := os.WriteFile(newPath, []byte(diffNewGraph+"github.com/example/main github.com/subdep@v1.0.0\n"), 0o600); err != nil {
+		t.Fatalf("failed to write graph file: %v", err)
+	}
+
+	output, err := executeRoot(t, "diff", "--depth-only", oldPath, newPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "github.com/subdep@v1.0.0: depth 2 -> 1\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import "errors"
+
+// Exit codes returned by the tangled binary, so scripts and CI jobs can
+// branch on why a run failed
+const (
+	ExitOK     = 0 // success
+	ExitError  = 1 // invalid flags or any other failure
+	ExitParse  = 2 // an input file could not be read or parsed
+	ExitRender = 3 // output could not be rendered or written
+	ExitPolicy = 4 // a license, baseline, budget or validation policy was violated
+	ExitCycles = 5 // validate found cycles listed with --fatal
+)
+
+// exitError tags an error with the exit code the process should return
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode tags err with an exit code, returning nil for a nil error
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode returns the exit code for an error returned by Execute: ExitOK
+// for nil, the code the error was tagged with, or ExitError otherwise
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var tagged *exitError
+	if errors.As(err, &tagged) {
+		return tagged.code
+	}
+	return ExitError
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	badPath := writeGraphFile(t, "not a graph\n")
+	cyclePath := writeGraphFile(t, testGraph+"github.com/subdep@v1.0.0 github.com/dep1@v1.0.0\n")
+	licensesPath := filepath.Join(t.TempDir(), "licenses.txt")
+	if err := os.WriteFile(licensesPath, []byte("github.com/dep1 GPL-3.0\n"), 0o600); err != nil {
+		t.Fatalf("failed to write licenses file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"success", []string{graphPath}, ExitOK},
+		{"invalid flag", []string{"--indent", "0", graphPath}, ExitError},
+		{"parse error", []string{badPath}, ExitParse},
+		{"policy violation", []string{"--licenses", licensesPath, "--deny-license", "GPL-3.0", graphPath}, ExitPolicy},
+		{"budget exceeded", []string{"--max-deps", "1", graphPath}, ExitPolicy},
+		{"cycles found", []string{"validate", "--fatal", "cycle", cyclePath}, ExitCycles},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeRoot(t, tt.args...)
+			if got := ExitCode(err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode_Wrapped(t *testing.T) {
+	err := withExitCode(ExitRender, errors.New("disk full"))
+	if got := ExitCode(errors.Join(errors.New("context"), err)); got != ExitRender {
+		t.Errorf("ExitCode() = %d, want %d for a wrapped tagged error", got, ExitRender)
+	}
+	if withExitCode(ExitRender, nil) != nil {
+		t.Error("withExitCode(nil) should return nil")
+	}
+}
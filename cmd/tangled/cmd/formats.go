@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scottbrown/tangled"
+)
+
+// allFormats is the --format value that renders every text format at once
+const allFormats = "all"
+
+// formatNames returns the canonical name of every registered format,
+// built-in formats first
+func formatNames() []string {
+	formats := tangled.Formats()
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.Names[0]
+	}
+	return names
+}
+
+// lookupFormat creates the renderer registered for a format name or alias
+func lookupFormat(name string) (tangled.Renderer, error) {
+	name = strings.ToLower(name)
+	if renderer, ok := tangled.LookupRenderer(name); ok {
+		return renderer, nil
+	}
+
+	supported := strings.Join(formatNames(), ", ")
+	if suggestion := suggestFormat(name); suggestion != "" {
+		return nil, fmt.Errorf("unsupported output format: %s, did you mean %s? (supported: %s)", name, suggestion, supported)
+	}
+	return nil, fmt.Errorf("unsupported output format: %s (supported: %s)", name, supported)
+}
+
+// suggestFormat returns the known format name closest to name, or "" if none is close enough
+func suggestFormat(name string) string {
+	const maxDistance = 2
+
+	best := ""
+	bestDistance := maxDistance + 1
+	for _, n := range tangled.RendererNames() {
+		if d := levenshtein(name, n); d < bestDistance {
+			best = n
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
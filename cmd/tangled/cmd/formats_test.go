@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/scottbrown/tangled"
+)
+
+func TestLookupFormat(t *testing.T) {
+	for _, f := range tangled.Formats() {
+		for _, name := range f.Names {
+			if _, err := lookupFormat(name); err != nil {
+				t.Errorf("lookupFormat(%q) error = %v", name, err)
+			}
+		}
+	}
+
+	if _, err := lookupFormat("DOT"); err != nil {
+		t.Errorf("lookupFormat() should be case-insensitive, got %v", err)
+	}
+}
+
+func TestLookupFormat_Registered(t *testing.T) {
+	tangled.RegisterRenderer("test-registered", func() tangled.Renderer { return tangled.NewSummaryRenderer() })
+
+	renderer, err := lookupFormat("test-registered")
+	if err != nil {
+		t.Fatalf("lookupFormat() error = %v for a registered renderer", err)
+	}
+	if _, ok := renderer.(*tangled.SummaryRenderer); !ok {
+		t.Errorf("lookupFormat() = %T, want the registered renderer", renderer)
+	}
+
+	if !slices.Contains(formatNames(), "test-registered") {
+		t.Errorf("formatNames() = %v, want the registered format listed", formatNames())
+	}
+	if _, err := lookupFormat("spreadsheet"); err == nil || !strings.Contains(err.Error(), "test-registered") {
+		t.Errorf("unsupported format error should list the registered format, got %v", err)
+	}
+
+	graphPath := writeGraphFile(t, testGraph)
+	outDir := filepath.Join(t.TempDir(), "out")
+	if _, err := executeRoot(t, "-f", "all", "--output-dir", outDir, graphPath); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	base := strings.TrimSuffix(filepath.Base(graphPath), filepath.Ext(graphPath))
+	if _, err := os.Stat(filepath.Join(outDir, base+".test-registered")); err != nil {
+		t.Errorf("--format all should render the registered format: %v", err)
+	}
+}
+
+func TestLookupFormatSuggestion(t *testing.T) {
+	tests := []struct {
+		input      string
+		suggestion string
+	}{
+		{input: "grpahviz", suggestion: "did you mean graphviz?"},
+		{input: "mermiad", suggestion: "did you mean mermaid?"},
+		{input: "jsn", suggestion: "did you mean json?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := lookupFormat(tt.input)
+			if err == nil {
+				t.Fatal("lookupFormat() should fail for unknown formats")
+			}
+			if !strings.Contains(err.Error(), tt.suggestion) {
+				t.Errorf("error %q should contain %q", err, tt.suggestion)
+			}
+		})
+	}
+
+	_, err := lookupFormat("spreadsheet")
+	if err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("unrelated format should not produce a suggestion, got %v", err)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"dot", "dot", 0},
+		{"dot", "dto", 2},
+		{"html", "htm", 1},
+		{"", "text", 4},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// boundedInput reads the graph file within the --max-input-bytes and
+// --input-timeout limits, failing with a clear error once either is exceeded
+// instead of reading without bound
+type boundedInput struct {
+	r        io.Reader
+	ctx      context.Context
+	read     int64
+	maxBytes int64
+	timeout  time.Duration
+	err      error // the first limit exceeded, if any
+}
+
+func (b *boundedInput) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if b.ctx.Err() != nil {
+		return 0, b.exceeded(b.timeoutError())
+	}
+
+	n, err := b.r.Read(p)
+	b.read += int64(n)
+	if b.maxBytes > 0 && b.read > b.maxBytes {
+		return n, b.exceeded(fmt.Errorf("input exceeds the --max-input-bytes limit of %d bytes", b.maxBytes))
+	}
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return n, b.exceeded(b.timeoutError())
+	}
+	return n, err
+}
+
+// exceeded records the limit that stopped reading so it can be reported in
+// place of any parse error the truncated input causes
+func (b *boundedInput) exceeded(err error) error {
+	b.err = err
+	return err
+}
+
+// timeoutError reports that reading did not finish within --input-timeout
+func (b *boundedInput) timeoutError() error {
+	return fmt.Errorf("input not read within the --input-timeout of %s", b.timeout)
+}
+
+// openInput opens the graph file for reading within the given limits; zero
+// disables a limit. The returned function releases the file and timer.
+func openInput(path string, maxBytes int64, timeout time.Duration) (*boundedInput, func(), error) {
+	file, err := os.Open(path) // #nosec G304 -- CLI tool, filename from user-provided command line argument
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		// Pipes and FIFOs honour read deadlines, so a stalled writer cannot
+		// block forever; regular files do not support them and never stall
+		deadline, _ := ctx.Deadline()
+		_ = file.SetReadDeadline(deadline)
+	}
+
+	var reader io.Reader = file
+	if maxBytes > 0 {
+		// Read one byte past the limit so exceeding it can be detected
+		reader = io.LimitReader(file, maxBytes+1)
+	}
+
+	input := &boundedInput{r: reader, ctx: ctx, maxBytes: maxBytes, timeout: timeout}
+	return input, func() {
+		cancel()
+		_ = file.Close()
+	}, nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestOpenInput_MaxBytes(t *testing.T) {
+	path := writeGraphFile(t, testGraph)
+
+	reader, closeInput, err := openInput(path, 10, 0)
+	if err != nil {
+		t.Fatalf("openInput() error = %v", err)
+	}
+	defer closeInput()
+
+	data, err := io.ReadAll(reader)
+	if err == nil || !strings.Contains(err.Error(), "exceeds the --max-input-bytes limit of 10 bytes") {
+		t.Errorf("ReadAll() error = %v, want the byte limit error", err)
+	}
+	if len(data) > 11 {
+		t.Errorf("read %d bytes, want reading to stop just past the limit", len(data))
+	}
+
+	reader, closeInput, err = openInput(path, int64(len(testGraph)), 0)
+	if err != nil {
+		t.Fatalf("openInput() error = %v", err)
+	}
+	defer closeInput()
+	if data, err := io.ReadAll(reader); err != nil || string(data) != testGraph {
+		t.Errorf("ReadAll() = %q, %v, want the whole file within the limit", data, err)
+	}
+}
+
+func TestMaxInputBytesFlag(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	_, err := executeRoot(t, "--max-input-bytes", "16", graphPath)
+	if err == nil || !strings.Contains(err.Error(), "--max-input-bytes") {
+		t.Fatalf("Execute() error = %v, want the byte limit error", err)
+	}
+	if code := ExitCode(err); code != ExitParse {
+		t.Errorf("ExitCode() = %d, want %d for oversized input", code, ExitParse)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build unix
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestOpenInput_Timeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.fifo")
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		t.Skipf("mkfifo unavailable: %v", err)
+	}
+
+	// Hold the pipe open without writing, like a stalled producer
+	writerReady := make(chan *os.File, 1)
+	go func() {
+		writer, err := os.OpenFile(path, os.O_WRONLY, 0) // #nosec G304 -- test pipe in a temporary directory
+		if err == nil {
+			writerReady <- writer
+		}
+		close(writerReady)
+	}()
+
+	reader, closeInput, err := openInput(path, 0, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("openInput() error = %v", err)
+	}
+	defer closeInput()
+	if writer, ok := <-writerReady; ok {
+		defer writer.Close()
+	}
+
+	if _, err := io.ReadAll(reader); err == nil || !strings.Contains(err.Error(), "--input-timeout of 50ms") {
+		t.Errorf("ReadAll() error = %v, want the timeout error", err)
+	}
+}
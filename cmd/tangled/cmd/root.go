@@ -1,18 +1,113 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/scottbrown/tangled"
 	"github.com/spf13/cobra"
 )
 
 var (
-	outputFormat string
-	outputFile   string
+	outputFormat   string
+	outputFile     string
+	leavesOnly     bool
+	labelTemplate  string
+	outdated       bool
+	reverseTree    string
+	simulateRemove string
+	wrapLabels     int
+	collapseChains bool
+	ego            string
+	printRoot      bool
+	recordNodes    bool
+	licensesFile   string
+	denyLicenses   []string
+	canonicalize   bool
+	fullPaths      bool
+	lineEnding     string
+	ascii          bool
+	plainDot       bool
+	baselineFile   string
+	search         bool
+	noVersions     bool
+	withLines      bool
+	maxDeps        int
+	countEdges     bool
+	blastRadius    int
+	hideRootEdges  bool
+	links          bool
+	outputDir      string
+	aliases        []string
+	parallel       int
+	goModFile      string
+	replaceImpact  string
+	precompute     bool
+	flatten        bool
+	teamsFile      string
+	team           string
+	conflicts      bool
+	top            int
+	github         bool
+	indent         int
+	canvasWidth    int
+	canvasHeight   int
+	whyTarget      string
+	stableIDs      bool
+	hideCommon     bool
+	commonFile     string
+	viewFile       string
+	excludes       []string
+	majorSpread    bool
+	summarize      bool
+	summarizeAfter int
+	inputFormat    string
+	teeFile        string
+	withHeader     bool
+	breakCycles    bool
+	testDepsFile   string
+	prodOnly       bool
+	testOnly       bool
+	childCounts    bool
+	hideZeroCounts bool
+	transpose      bool
+	expandAll      bool
+	expandDepth    int
+	centrality     string
+	nearLeaves     int
+	cyclesJSON     bool
+	clipboard      bool
+	balance        bool
+	cssClasses     bool
+	focusFragment  bool
+	edgesByDepth   bool
+	layoutSeed     uint32
+	splitByRoot    bool
+	selfLoops      bool
+	maxInputBytes  int64
+	inputTimeout   time.Duration
+	ageHistogram   bool
+	datesFile      string
+	relaxBackEdges bool
+	changedFile    string
+	options        []string
+	orphanVersions bool
+	rankSep        float64
+	nodeSep        float64
+	dominators     bool
+	vulnsFile      string
+	maxEdges       int
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -20,7 +115,8 @@ var rootCmd = &cobra.Command{
 	Use:   "tangled [graph-file]",
 	Short: "Visualize Go module dependency graphs",
 	Long: `tangled parses the output from 'go mod graph' and generates
-various visualization formats including plaintext tree, HTML/D3, MermaidJS, and GraphViz DOT.
+various visualization formats including plaintext tree, HTML/D3, MermaidJS, GraphViz DOT,
+CSV, JSON, DGML, and a one-line summary.
 
 Example usage:
   go mod graph > deps.graph
@@ -32,55 +128,436 @@ Example usage:
 	RunE:    runRoot,
 }
 
-func runRoot(cmd *cobra.Command, args []string) error {
+func runRoot(cmd *cobra.Command, args []string) (err error) {
 	inputFile := args[0]
 
+	// Validate flags before doing any work
+	if wrapLabels < 0 {
+		return fmt.Errorf("--wrap-labels must not be negative")
+	}
+	if indent < 1 {
+		return fmt.Errorf("--indent must be positive")
+	}
+	mode, err := tangled.ParseCentralityMode(centrality)
+	if err != nil {
+		return err
+	}
+	centrality = string(mode)
+	if maxInputBytes < 0 || inputTimeout < 0 {
+		return fmt.Errorf("--max-input-bytes and --input-timeout must not be negative")
+	}
+	if nearLeaves < 0 {
+		return fmt.Errorf("--near-leaves must not be negative")
+	}
+	if maxEdges < 0 {
+		return fmt.Errorf("--max-edges must not be negative")
+	}
+	if cmd.Flags().Changed("ranksep") && !isPositiveFloat(rankSep) {
+		return fmt.Errorf("--ranksep must be a positive number")
+	}
+	if cmd.Flags().Changed("nodesep") && !isPositiveFloat(nodeSep) {
+		return fmt.Errorf("--nodesep must be a positive number")
+	}
+	if expandAll && expandDepth < 1 {
+		return fmt.Errorf("--expand-depth must be positive")
+	}
+	if summarize && summarizeAfter < 1 {
+		return fmt.Errorf("--summarize-after must be positive")
+	}
+	if prodOnly && testOnly {
+		return fmt.Errorf("--prod-only and --test-only cannot be combined")
+	}
+	if (prodOnly || testOnly) && testDepsFile == "" {
+		return fmt.Errorf("--prod-only and --test-only require --test-deps")
+	}
+	if canvasWidth < 1 || canvasHeight < 1 {
+		return fmt.Errorf("--width and --height must be positive")
+	}
+
+	if strings.EqualFold(outputFormat, allFormats) && outputDir == "" {
+		return fmt.Errorf("--format %s requires --output-dir", allFormats)
+	}
+	if splitByRoot && (outputDir == "" || strings.EqualFold(outputFormat, allFormats)) {
+		return fmt.Errorf("--split-by-root-child requires --output-dir and a single --format")
+	}
+	if splitByRoot && (outputFile != "" || clipboard || teeFile != "") {
+		return fmt.Errorf("--split-by-root-child cannot be combined with -o, --clipboard or --tee")
+	}
+	if splitByRoot {
+		for _, name := range reportFlags {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--split-by-root-child cannot be combined with --%s", name)
+			}
+		}
+	}
+	if clipboard && (outputFile != "" || strings.EqualFold(outputFormat, allFormats)) {
+		return fmt.Errorf("--clipboard cannot be combined with -o or --format %s", allFormats)
+	}
+	if strings.EqualFold(outputFormat, allFormats) && teeFile != "" {
+		return fmt.Errorf("--tee cannot be combined with --format %s", allFormats)
+	}
+	binary := false
+	if f, ok := tangled.LookupFormat(outputFormat); ok {
+		if f.Binary {
+			if outputFile == "" {
+				return fmt.Errorf("--format %s writes binary output and requires -o (use -o - for stdout)", outputFormat)
+			}
+			binary = true
+		}
+		if f.Target && whyTarget == "" {
+			return fmt.Errorf("--format %s requires --target", outputFormat)
+		}
+	}
+
+	wrapLineEnding, err := lineEndingWrapper(lineEnding)
+	if err != nil {
+		return err
+	}
+
+	labels, err := tangled.NewLabelTemplate(labelTemplate)
+	if err != nil {
+		return err
+	}
+	aliasMap, err := parseAliases(aliases)
+	if err != nil {
+		return err
+	}
+	optionMap, err := parseOptions(options)
+	if err != nil {
+		return err
+	}
+	if err := checkOptions(optionMap); err != nil {
+		return err
+	}
+	renderOptions := tangled.RenderOptions{Labels: labels, Aliases: aliasMap, Links: links, Header: withHeader, Options: optionMap}
+	if withHeader {
+		renderOptions.Generated = time.Now().UTC()
+	}
+
 	// Parse the dependency graph
-	graph, err := tangled.ParseGraphFromFile(inputFile)
+	graph, err := parseInput(inputFile)
 	if err != nil {
-		return fmt.Errorf("failed to parse graph file: %w", err)
+		return withExitCode(ExitParse, fmt.Errorf("failed to parse graph file: %w", err))
+	}
+
+	// Report the identified main module and stop when requested
+	if printRoot {
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), graph.MainModule.String())
+		return err
+	}
+
+	// Attach licenses and enforce the license policy
+	if licensesFile != "" {
+		licenses, err := tangled.ParseLicensesFromFile(licensesFile)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse licenses file: %w", err))
+		}
+		graph.ApplyLicenses(licenses)
+	}
+	if vulnsFile != "" {
+		vulns, err := tangled.ParseGovulncheckFromFile(vulnsFile)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse vulns file: %w", err))
+		}
+		graph.ApplyVulnerabilities(vulns)
+	}
+	if len(denyLicenses) > 0 {
+		if licensesFile == "" {
+			return fmt.Errorf("--deny-license requires --licenses")
+		}
+		if violations := graph.CheckLicenses(denyLicenses); len(violations) > 0 {
+			for _, v := range violations {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", v.Module.String(), v.License)
+			}
+			return withExitCode(ExitPolicy, fmt.Errorf("license policy violated by %d modules", len(violations)))
+		}
+	}
+
+	// Scope modules by whether production code or only tests need them
+	if testDepsFile != "" {
+		testDeps, err := tangled.ParseModulePathsFromFile(testDepsFile)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse test dependencies file: %w", err))
+		}
+		graph.ApplyTestScope(testDeps)
+	}
+
+	// Check selected versions against the baseline
+	if baselineFile != "" {
+		baseline, err := tangled.ParseBaselineFromFile(baselineFile)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse baseline file: %w", err))
+		}
+		if mismatches := graph.CompareBaseline(baseline); len(mismatches) > 0 {
+			for _, m := range mismatches {
+				fmt.Fprintln(cmd.ErrOrStderr(), m.String())
+			}
+			return withExitCode(ExitPolicy, fmt.Errorf("%d modules differ from the baseline", len(mismatches)))
+		}
+	}
+
+	// Enforce the transitive dependency budget
+	if maxDeps > 0 {
+		count := len(graph.GetTransitiveDependencies(graph.MainModule))
+		fmt.Fprintf(cmd.ErrOrStderr(), "%d transitive dependencies (budget %d)\n", count, maxDeps)
+		if count > maxDeps {
+			return withExitCode(ExitPolicy, fmt.Errorf("%d transitive dependencies exceed the budget of %d", count, maxDeps))
+		}
+	}
+
+	// Apply graph transforms
+	if viewFile != "" {
+		view, err := tangled.ParseViewFromFile(viewFile)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse view file: %w", err))
+		}
+		if graph, err = view.Apply(graph); err != nil {
+			return err
+		}
+	}
+	if len(excludes) > 0 {
+		graph = graph.Exclude(excludes)
+	}
+	if team != "" {
+		if teamsFile == "" {
+			return fmt.Errorf("--team requires --teams")
+		}
+		teams, err := tangled.ParseTeamsFromFile(teamsFile)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse teams file: %w", err))
+		}
+		graph = graph.FilterByTeam(teams, team)
+	}
+	if prodOnly {
+		graph = graph.FilterScope(tangled.ScopeProd)
+	}
+	if testOnly {
+		graph = graph.FilterScope(tangled.ScopeTest)
+	}
+	if hideCommon {
+		common := tangled.DefaultCommonModules
+		if commonFile != "" {
+			if common, err = tangled.ParseModulePathsFromFile(commonFile); err != nil {
+				return withExitCode(ExitParse, fmt.Errorf("failed to parse common modules file: %w", err))
+			}
+		}
+		graph = graph.Exclude(common)
+	}
+	if simulateRemove != "" {
+		module, ok := graph.FindModule(simulateRemove)
+		if !ok {
+			return fmt.Errorf("module not found in graph: %s", simulateRemove)
+		}
+		graph = graph.SimulateRemoval(module)
+	}
+	if nearLeaves > 0 {
+		graph = graph.NearLeaves(nearLeaves)
+	}
+	if ego != "" {
+		query, radius, err := parseEgo(ego)
+		if err != nil {
+			return err
+		}
+		center, ok := graph.FindModule(query)
+		if !ok {
+			return fmt.Errorf("module not found in graph: %s", query)
+		}
+		if graph, err = graph.EgoNetwork(center, radius); err != nil {
+			return err
+		}
+	}
+	if changedFile != "" {
+		paths, err := tangled.ParseModulePathsFromFile(changedFile)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse changed modules file: %w", err))
+		}
+		graph = graph.Changed(paths)
+	}
+	if collapseChains {
+		graph = graph.CollapseChains()
+	}
+	if hideRootEdges {
+		graph = graph.HideRootEdges()
+	}
+	if flatten {
+		graph = graph.Flatten()
+	}
+	if top > 0 {
+		graph = graph.TopCentralBy(top, tangled.CentralityMode(centrality))
+	}
+	if maxEdges > 0 {
+		graph, renderOptions.DroppedEdges = graph.LimitEdges(maxEdges)
+		if dropped := renderOptions.DroppedEdges; dropped > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Dropped %d edges furthest from the main module to stay within --max-edges %d\n", dropped, maxEdges)
+		}
+	}
+	if transpose {
+		graph = graph.Transpose()
+	}
+	if canonicalize {
+		graph.Canonicalize()
+	}
+
+	// Render every text format into the output directory when requested
+	if strings.EqualFold(outputFormat, allFormats) {
+		return renderAll(cmd, graph, renderOptions, inputFile, wrapLineEnding)
 	}
 
 	// Create the appropriate renderer
-	var renderer tangled.Renderer
-	switch strings.ToLower(outputFormat) {
-	case "text", "plaintext", "tree":
-		renderer = tangled.NewPlaintextRenderer()
-	case "html", "d3":
-		renderer = tangled.NewHTMLRenderer()
-	case "mermaid", "mmd":
-		renderer = tangled.NewMermaidRenderer()
-	case "dot", "graphviz":
-		renderer = tangled.NewGraphvizRenderer()
-	default:
-		return fmt.Errorf("unsupported output format: %s (supported: text, html, mermaid, dot)", outputFormat)
+	renderer, err := lookupFormat(outputFormat)
+	if err != nil {
+		return err
+	}
+	configureRenderer(renderer, renderOptions)
+
+	// Render each subtree of the main module into its own file when requested
+	if splitByRoot {
+		return renderSplit(cmd, graph, renderer, inputFile, wrapLineEnding)
 	}
 
 	// Determine output destination
-	var writer *os.File
-	if outputFile == "" || outputFile == "-" {
-		writer = os.Stdout
+	var writer io.Writer
+	if clipboard {
+		// Collect the output and copy it once everything has been written
+		var copied bytes.Buffer
+		defer func() {
+			if err != nil {
+				return
+			}
+			if copyErr := copyToClipboard(copied.Bytes()); copyErr != nil {
+				err = withExitCode(ExitRender, fmt.Errorf("failed to copy to clipboard: %w", copyErr))
+			}
+		}()
+		writer = &copied
+	} else if outputFile == "" || outputFile == "-" {
+		writer = cmd.OutOrStdout()
 	} else {
 		file, err := os.Create(outputFile) // #nosec G304 -- CLI tool, output file from user-provided command line flag
 		if err != nil {
-			return fmt.Errorf("failed to create output file: %w", err)
+			return withExitCode(ExitRender, fmt.Errorf("failed to create output file: %w", err))
 		}
 		defer file.Close()
 		writer = file
 	}
+	if teeFile != "" {
+		// Copy the output to the tee file, which only appears once everything
+		// has been written successfully
+		tee, teeErr := createAtomic(teeFile)
+		if teeErr != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to create tee file: %w", teeErr))
+		}
+		defer func() {
+			if err != nil {
+				tee.abort()
+			} else if commitErr := tee.commit(); commitErr != nil {
+				err = withExitCode(ExitRender, fmt.Errorf("failed to write tee file: %w", commitErr))
+			}
+		}()
+		writer = io.MultiWriter(writer, tee)
+	}
+	if !binary {
+		writer = wrapLineEnding(writer)
+	}
 
-	// Render the graph
-	if htmlRenderer, ok := renderer.(*tangled.HTMLRenderer); ok {
-		// For HTML renderer, pass the filename for dynamic title
-		filename := filepath.Base(inputFile)
-		if err := htmlRenderer.RenderWithFilename(graph, writer, filename); err != nil {
-			return fmt.Errorf("failed to render graph: %w", err)
+	// List modules instead of rendering when requested
+	if leavesOnly {
+		return writeModules(writer, graph.LeafModules())
+	}
+	if outdated {
+		return writeModules(writer, graph.OutdatedHeuristic())
+	}
+	if blastRadius > 0 {
+		return writeBlastRadius(writer, graph, blastRadius)
+	}
+	if conflicts {
+		for _, conflict := range graph.FindIndirectConflicts() {
+			if _, err := fmt.Fprintln(writer, conflict.String()); err != nil {
+				return withExitCode(ExitRender, fmt.Errorf("failed to write conflicts: %w", err))
+			}
 		}
-	} else {
-		// For other renderers, use the standard render method
-		if err := renderer.Render(graph, writer); err != nil {
-			return fmt.Errorf("failed to render graph: %w", err)
+		return nil
+	}
+	if orphanVersions {
+		for _, orphan := range graph.OrphanVersions() {
+			if _, err := fmt.Fprintln(writer, orphan.String()); err != nil {
+				return withExitCode(ExitRender, fmt.Errorf("failed to write orphan versions: %w", err))
+			}
+		}
+		return nil
+	}
+	if majorSpread {
+		return writeMajorSpread(writer, graph)
+	}
+	if breakCycles {
+		return writeEdges(writer, graph.FeedbackEdges())
+	}
+	if dominators {
+		return writeDominators(writer, graph)
+	}
+	if selfLoops {
+		return writeEdges(writer, graph.SelfLoops())
+	}
+	if cyclesJSON {
+		return writeCyclesJSON(writer, graph)
+	}
+	if balance {
+		return writeBalance(writer, graph)
+	}
+	if edgesByDepth {
+		return writeEdgesByDepth(writer, graph)
+	}
+	if ageHistogram {
+		var dates map[string]time.Time
+		if datesFile != "" {
+			if dates, err = tangled.ParseModuleDatesFromFile(datesFile); err != nil {
+				return withExitCode(ExitParse, fmt.Errorf("failed to parse dates file: %w", err))
+			}
 		}
+		return writeAgeHistogram(writer, graph.AgeHistogram(time.Now().UTC(), dates))
+	}
+	if goModFile != "" {
+		requires, err := tangled.ParseGoModRequiresFromFile(goModFile)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse go.mod file: %w", err))
+		}
+		return writeModules(writer, graph.UnusedRequires(requires))
+	}
+	if replaceImpact != "" {
+		replaces, err := tangled.ParseGoModReplacesFromFile(replaceImpact)
+		if err != nil {
+			return withExitCode(ExitParse, fmt.Errorf("failed to parse go.mod file: %w", err))
+		}
+		return writeModules(writer, graph.ReplaceImpact(replaces))
+	}
+
+	// Render the tree of dependents instead of dependencies when requested
+	if reverseTree != "" {
+		target, ok := graph.FindModule(reverseTree)
+		if !ok {
+			return fmt.Errorf("module not found in graph: %s", reverseTree)
+		}
+		plaintext := tangled.NewPlaintextRenderer()
+		plaintext.SetRenderOptions(renderOptions)
+		plaintext.Indent = indent
+		if summarize {
+			plaintext.Summarize = summarizeAfter
+		}
+		plaintext.ChildCounts = childCounts
+		plaintext.HideZeroCounts = hideZeroCounts
+		plaintext.ExpandAll = expandAll
+		plaintext.ExpandDepth = expandDepth
+		if ascii {
+			plaintext.Charset = tangled.ASCIICharset
+		}
+		if err := plaintext.RenderReverse(graph, target, writer); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to render graph: %w", err))
+		}
+		return nil
+	}
+
+	// Render the graph
+	if err := renderGraph(renderer, graph, writer, inputFile); err != nil {
+		return err
 	}
 
 	// Print success message to stderr if outputting to file
@@ -91,12 +568,549 @@ func runRoot(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// configureRenderer applies the shared options and any renderer-specific flags
+func configureRenderer(renderer tangled.Renderer, renderOptions tangled.RenderOptions) {
+	if configurable, ok := renderer.(tangled.Configurable); ok {
+		configurable.SetRenderOptions(renderOptions)
+	}
+	if plaintext, ok := renderer.(*tangled.PlaintextRenderer); ok {
+		plaintext.FullPaths = fullPaths
+		plaintext.Indent = indent
+		if summarize {
+			plaintext.Summarize = summarizeAfter
+		}
+		plaintext.ChildCounts = childCounts
+		plaintext.HideZeroCounts = hideZeroCounts
+		plaintext.ExpandAll = expandAll
+		plaintext.ExpandDepth = expandDepth
+		if ascii {
+			plaintext.Charset = tangled.ASCIICharset
+		}
+	}
+	if csv, ok := renderer.(*tangled.CSVRenderer); ok {
+		csv.WithLines = withLines
+	}
+	if json, ok := renderer.(*tangled.JSONRenderer); ok {
+		json.WithLines = withLines
+	}
+	if list, ok := renderer.(*tangled.ModuleListRenderer); ok {
+		list.NoVersions = noVersions
+	}
+	if html, ok := renderer.(*tangled.HTMLRenderer); ok {
+		html.DisableSearch = !search
+		html.PrecomputeLayout = precompute
+		html.Width = canvasWidth
+		html.Height = canvasHeight
+		html.StableIDs = stableIDs
+		html.Centrality = tangled.CentralityMode(centrality)
+		html.CSSClasses = cssClasses
+		html.FocusFragment = focusFragment
+		html.Seed = layoutSeed
+	}
+	if why, ok := renderer.(*tangled.WhyRenderer); ok {
+		why.Target = whyTarget
+	}
+	if mermaid, ok := renderer.(*tangled.MermaidRenderer); ok {
+		mermaid.GitHub = github
+	}
+	if dot, ok := renderer.(*tangled.GraphvizRenderer); ok {
+		dot.WrapLabels = wrapLabels
+		dot.RecordNodes = recordNodes
+		dot.Plain = plainDot
+		dot.CollapseDuplicates = countEdges
+		dot.RelaxBackEdges = relaxBackEdges
+		dot.RankSep = rankSep
+		dot.NodeSep = nodeSep
+	}
+}
+
+// renderGraph renders the graph, passing the input file name to renderers
+// that use it
+func renderGraph(renderer tangled.Renderer, graph *tangled.DependencyGraph, writer io.Writer, inputFile string) error {
+	var err error
+	if fileAware, ok := renderer.(tangled.FileAwareRenderer); ok {
+		// For HTML renderer, pass the filename for dynamic title
+		err = fileAware.RenderWithFilename(graph, writer, filepath.Base(inputFile))
+	} else {
+		err = renderer.Render(graph, writer)
+	}
+	if err != nil {
+		return withExitCode(ExitRender, fmt.Errorf("failed to render graph: %w", err))
+	}
+	return nil
+}
+
+// renderAll renders every text format into --output-dir, naming each file
+// after the input file with the format's suffix
+func renderAll(cmd *cobra.Command, graph *tangled.DependencyGraph, renderOptions tangled.RenderOptions, inputFile string, wrapLineEnding func(io.Writer) io.Writer) error {
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return withExitCode(ExitRender, fmt.Errorf("failed to create output directory: %w", err))
+	}
+
+	base := strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile))
+	for _, f := range tangled.Formats() {
+		if f.Binary {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Skipping binary format %s\n", f.Names[0])
+			continue
+		}
+		if f.Target && whyTarget == "" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Skipping format %s without --target\n", f.Names[0])
+			continue
+		}
+
+		renderer, err := lookupFormat(f.Names[0])
+		if err != nil {
+			return err
+		}
+		configureRenderer(renderer, renderOptions)
+
+		path := filepath.Join(outputDir, base+f.Suffix)
+		if err := renderFile(renderer, graph, path, inputFile, wrapLineEnding); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Successfully generated %s output in %s\n", f.Names[0], path)
+	}
+	return nil
+}
+
+// reportFlags are the flags that print a report or reverse tree in place of
+// the rendered graph
+var reportFlags = []string{
+	"leaves-only", "outdated", "blast-radius", "indirect-conflicts", "orphan-versions",
+	"major-spread", "break-cycles", "dominators", "self-loops", "cycles-json", "balance",
+	"edges-by-depth", "age-histogram", "gomod", "replace-impact", "reverse-tree",
+}
+
+// renderSplit renders one file per direct dependency of the main module into
+// --output-dir, each holding the main module and that dependency's subtree
+func renderSplit(cmd *cobra.Command, graph *tangled.DependencyGraph, renderer tangled.Renderer, inputFile string, wrapLineEnding func(io.Writer) io.Writer) error {
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return withExitCode(ExitRender, fmt.Errorf("failed to create output directory: %w", err))
+	}
+
+	suffix := ".out"
+	if f, ok := tangled.LookupFormat(outputFormat); ok {
+		suffix = f.Suffix
+	}
+	base := strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile))
+	written := make(map[string]bool)
+	for _, child := range graph.GetDirectDependencies(graph.MainModule) {
+		name := strings.NewReplacer("/", "_", "@", "_").Replace(child.String())
+		if written[name] {
+			continue
+		}
+		written[name] = true
+
+		path := filepath.Join(outputDir, base+"."+name+suffix)
+		if err := renderFile(renderer, graph.RootSubtree(child), path, inputFile, wrapLineEnding); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Successfully generated %s output in %s\n", outputFormat, path)
+	}
+	return nil
+}
+
+// renderFile renders the graph into a new file at path
+func renderFile(renderer tangled.Renderer, graph *tangled.DependencyGraph, path, inputFile string, wrapLineEnding func(io.Writer) io.Writer) error {
+	file, err := os.Create(path) // #nosec G304 -- CLI tool, output directory from user-provided command line flag
+	if err != nil {
+		return withExitCode(ExitRender, fmt.Errorf("failed to create output file: %w", err))
+	}
+	err = renderGraph(renderer, graph, wrapLineEnding(file), inputFile)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// writeCyclesJSON writes the graph's cycles, in canonical order, as a JSON
+// array of arrays of module strings
+func writeCyclesJSON(writer io.Writer, graph *tangled.DependencyGraph) error {
+	cycles := make([][]string, 0)
+	for _, cycle := range graph.CanonicalCycles() {
+		keys := make([]string, len(cycle))
+		for i, module := range cycle {
+			keys[i] = module.String()
+		}
+		cycles = append(cycles, keys)
+	}
+
+	if err := json.NewEncoder(writer).Encode(cycles); err != nil {
+		return withExitCode(ExitRender, fmt.Errorf("failed to write cycles: %w", err))
+	}
+	return nil
+}
+
+// writeBalance writes the branching factor, module count per depth and skew
+// of the tree below the main module, one "name: value" line each
+func writeBalance(writer io.Writer, graph *tangled.DependencyGraph) error {
+	balance := graph.TreeBalance()
+
+	lines := []string{fmt.Sprintf("branching factor: %.2f", balance.BranchingFactor)}
+	for depth, count := range balance.DepthCounts {
+		lines = append(lines, fmt.Sprintf("depth %d: %d", depth, count))
+	}
+	lines = append(lines, fmt.Sprintf("skew: %.2f", balance.Skew))
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(writer, line); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write balance: %w", err))
+		}
+	}
+	return nil
+}
+
+// writeEdgesByDepth writes the number of edges leaving each depth, from the
+// main module down, as "depth N: count"
+func writeEdgesByDepth(writer io.Writer, graph *tangled.DependencyGraph) error {
+	counts := graph.EdgesByDepth()
+	depths := make([]int, 0, len(counts))
+	for depth := range counts {
+		depths = append(depths, depth)
+	}
+	sort.Ints(depths)
+
+	for _, depth := range depths {
+		if _, err := fmt.Fprintf(writer, "depth %d: %d\n", depth, counts[depth]); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write edge counts: %w", err))
+		}
+	}
+	return nil
+}
+
+// writeAgeHistogram writes one "label: count" line per age bucket, followed by
+// the bucket's modules indented beneath it
+func writeAgeHistogram(writer io.Writer, buckets []tangled.AgeBucket) error {
+	var lines []string
+	for _, bucket := range buckets {
+		lines = append(lines, fmt.Sprintf("%s: %d", bucket.Label, len(bucket.Modules)))
+		for _, module := range bucket.Modules {
+			lines = append(lines, "  "+module.String())
+		}
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(writer, line); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write age histogram: %w", err))
+		}
+	}
+	return nil
+}
+
+// writeDominators writes "module: count" for every module other than the main
+// module that dominates others, counting the modules that removing it would
+// cut off, from the most to the fewest
+func writeDominators(writer io.Writer, graph *tangled.DependencyGraph) error {
+	idom := graph.Dominators()
+	root := graph.MainModule.String()
+
+	// Credit each module to every dominator on its chain up to the main module
+	counts := make(map[string]int)
+	for module := range idom {
+		for dominator := idom[module]; dominator != root; dominator = idom[dominator] {
+			counts[dominator]++
+		}
+	}
+
+	modules := make([]string, 0, len(counts))
+	for module := range counts {
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if counts[modules[i]] != counts[modules[j]] {
+			return counts[modules[i]] > counts[modules[j]]
+		}
+		return modules[i] < modules[j]
+	})
+
+	for _, module := range modules {
+		if _, err := fmt.Fprintf(writer, "%s: %d\n", module, counts[module]); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write dominators: %w", err))
+		}
+	}
+	return nil
+}
+
+// writeEdges writes one "from -> to" line per edge
+func writeEdges(writer io.Writer, edges []tangled.Dependency) error {
+	for _, dep := range edges {
+		if _, err := fmt.Fprintf(writer, "%s -> %s\n", dep.From.String(), dep.To.String()); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write edges: %w", err))
+		}
+	}
+	return nil
+}
+
+// writeMajorSpread writes each module path present at more than one major
+// version, sorted by path, as "path: v1, v2"
+func writeMajorSpread(writer io.Writer, graph *tangled.DependencyGraph) error {
+	spread := graph.MajorVersionSpread()
+	paths := make([]string, 0, len(spread))
+	for path, majors := range spread {
+		if len(majors) > 1 {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		versions := make([]string, len(spread[path]))
+		for i, major := range spread[path] {
+			versions[i] = "v" + strconv.Itoa(major)
+		}
+		if _, err := fmt.Fprintf(writer, "%s: %s\n", path, strings.Join(versions, ", ")); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write major versions: %w", err))
+		}
+	}
+	return nil
+}
+
+// parseInput parses the graph file in the format selected by --input-format
+func parseInput(inputFile string) (*tangled.DependencyGraph, error) {
+	reader, closeInput, err := openInput(inputFile, maxInputBytes, inputTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer closeInput()
+
+	var graph *tangled.DependencyGraph
+	switch strings.ToLower(inputFormat) {
+	case "gomodgraph":
+		graph, err = tangled.ParseGraph(reader)
+	case "bazel":
+		graph, err = tangled.ParseBazelGraph(reader)
+	default:
+		return nil, fmt.Errorf("unsupported input format: %s (supported: gomodgraph, bazel)", inputFormat)
+	}
+	// A limit cuts the input short, so report it rather than the parse error
+	// the truncated input may have caused
+	if reader.err != nil {
+		return nil, reader.err
+	}
+	return graph, err
+}
+
+// parseEgo splits an --ego value of the form module[:radius], defaulting the radius to 1
+func parseEgo(value string) (string, int, error) {
+	idx := strings.LastIndex(value, ":")
+	if idx == -1 {
+		return value, 1, nil
+	}
+
+	radius, err := strconv.Atoi(value[idx+1:])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid ego radius %q: %w", value[idx+1:], err)
+	}
+	if radius < 0 {
+		return "", 0, fmt.Errorf("ego radius must not be negative, got %d", radius)
+	}
+	return value[:idx], radius, nil
+}
+
+// parseAliases builds a module path to display label map from path=Label values
+func parseAliases(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	aliasMap := make(map[string]string, len(values))
+	for _, value := range values {
+		path, label, ok := strings.Cut(value, "=")
+		if !ok || path == "" || label == "" {
+			return nil, fmt.Errorf("invalid alias %q, expected path=Label", value)
+		}
+		aliasMap[path] = label
+	}
+	return aliasMap, nil
+}
+
+// isPositiveFloat reports whether value is a finite number above zero
+func isPositiveFloat(value float64) bool {
+	return value > 0 && !math.IsInf(value, 1)
+}
+
+// parseOptions turns repeated --option key=value flags into a map, with later
+// values for a key replacing earlier ones
+func parseOptions(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	optionMap := make(map[string]string, len(values))
+	for _, value := range values {
+		key, setting, ok := strings.Cut(value, "=")
+		if !ok || key == "" || setting == "" {
+			return nil, fmt.Errorf("invalid option %q, expected key=value", value)
+		}
+		optionMap[key] = setting
+	}
+	return optionMap, nil
+}
+
+// checkOptions rejects --option keys that no selected renderer reads, which
+// are every format with --format all. An unknown format is left for
+// lookupFormat to report.
+func checkOptions(optionMap map[string]string) error {
+	if len(optionMap) == 0 {
+		return nil
+	}
+
+	names := []string{outputFormat}
+	if strings.EqualFold(outputFormat, allFormats) {
+		names = formatNames()
+	}
+	accepted := make(map[string]bool)
+	for _, name := range names {
+		renderer, ok := tangled.LookupRenderer(name)
+		if !ok {
+			return nil
+		}
+		if keyer, ok := renderer.(tangled.OptionKeyer); ok {
+			for _, key := range keyer.OptionKeys() {
+				accepted[key] = true
+			}
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(optionMap)) {
+		if !accepted[key] {
+			if len(accepted) == 0 {
+				return fmt.Errorf("unknown option %q: --format %s takes no options", key, outputFormat)
+			}
+			return fmt.Errorf("unknown option %q for --format %s (supported: %s)", key, outputFormat, strings.Join(slices.Sorted(maps.Keys(accepted)), ", "))
+		}
+	}
+	return nil
+}
+
+// writeModules writes one module per line
+func writeModules(writer io.Writer, modules []tangled.Module) error {
+	for _, module := range modules {
+		if _, err := fmt.Fprintln(writer, module.String()); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write modules: %w", err))
+		}
+	}
+	return nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
 	return rootCmd.Execute()
 }
 
+// writeBlastRadius writes the top modules by blast radius with their counts,
+// largest first
+func writeBlastRadius(writer io.Writer, graph *tangled.DependencyGraph, top int) error {
+	radius := graph.BlastRadiusParallel(parallel)
+	modules := make([]string, 0, len(radius))
+	for module := range radius {
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if radius[modules[i]] != radius[modules[j]] {
+			return radius[modules[i]] > radius[modules[j]]
+		}
+		return modules[i] < modules[j]
+	})
+	if len(modules) > top {
+		modules = modules[:top]
+	}
+
+	for _, module := range modules {
+		if _, err := fmt.Fprintf(writer, "%d %s\n", radius[module], module); err != nil {
+			return withExitCode(ExitRender, fmt.Errorf("failed to write blast radius: %w", err))
+		}
+	}
+	return nil
+}
+
 func init() {
-	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "text", "Output format (text, html, mermaid, dot)")
+	rootCmd.Flags().StringVarP(&outputFormat, "format", "f", "text", "Output format ("+strings.Join(formatNames(), ", ")+", or "+allFormats+" with --output-dir)")
 	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
+	rootCmd.Flags().StringVar(&outputDir, "output-dir", "", "Directory for the files written by --format "+allFormats)
+	rootCmd.Flags().StringVar(&labelTemplate, "label-template", tangled.DefaultLabelTemplate, "Go text/template for node labels (fields: .Path, .Version, .Depth)")
+	rootCmd.Flags().BoolVar(&printRoot, "print-root", false, "Print the identified main module and exit without rendering")
+	rootCmd.Flags().BoolVar(&leavesOnly, "leaves-only", false, "List only modules with no dependencies, one per line")
+	rootCmd.Flags().StringVar(&reverseTree, "reverse-tree", "", "Render a plaintext tree of everything that depends on the given module")
+	rootCmd.Flags().StringVar(&simulateRemove, "simulate-remove", "", "Remove a module and everything only reachable through it before rendering")
+	rootCmd.Flags().IntVar(&wrapLabels, "wrap-labels", 0, "Wrap DOT labels at path separators every N characters (0 disables)")
+	rootCmd.Flags().BoolVar(&collapseChains, "collapse-chains", false, "Collapse linear chains of single-parent, single-child modules into labeled edges")
+	rootCmd.Flags().StringVar(&ego, "ego", "", "Render only modules within a hop radius of a module, as module[:radius] (default radius 1)")
+	rootCmd.Flags().BoolVar(&recordNodes, "record-nodes", false, "Draw DOT nodes as records with separate path and version cells")
+	rootCmd.Flags().StringVar(&licensesFile, "licenses", "", "File mapping module paths to SPDX licenses (one \"path license\" pair per line)")
+	rootCmd.Flags().StringSliceVar(&denyLicenses, "deny-license", nil, "Fail if any module carries this license (repeatable)")
+	rootCmd.Flags().BoolVar(&canonicalize, "canonicalize", false, "Sort edges so output is identical regardless of input line order")
+	rootCmd.Flags().BoolVar(&fullPaths, "full-paths", false, "Show the full root-to-node path on each line of the text tree")
+	rootCmd.Flags().StringVar(&lineEnding, "line-ending", "lf", "Line ending for text output (lf, crlf)")
+	rootCmd.Flags().BoolVar(&ascii, "ascii", false, "Draw the text tree with ASCII connectors instead of box-drawing characters")
+	rootCmd.Flags().BoolVar(&plainDot, "plain-dot", false, "Emit DOT with only node IDs and edges, without styling attributes")
+	rootCmd.Flags().StringVar(&baselineFile, "baseline", "", "Fail if selected module versions differ from a file of \"path version\" pairs")
+	rootCmd.Flags().BoolVar(&search, "search", true, "Include the node search box in HTML output (use --search=false to omit)")
+	rootCmd.Flags().BoolVar(&noVersions, "no-versions", false, "List module paths without versions in the modules format")
+	rootCmd.Flags().BoolVar(&withLines, "with-lines", false, "Include the input line number of each edge in csv-edges and json output")
+	rootCmd.Flags().IntVar(&maxDeps, "max-deps", 0, "Fail if the main module has more than N transitive dependencies (0 disables)")
+	rootCmd.Flags().BoolVar(&countEdges, "count-edges", false, "Draw repeated DOT edges once, labeled with their count")
+	rootCmd.Flags().IntVar(&blastRadius, "blast-radius", 0, "List the N modules with the most transitive dependents, with their counts")
+	rootCmd.Flags().BoolVar(&hideRootEdges, "hide-root-edges", false, "Drop edges originating from the main module, keeping the deeper structure")
+	rootCmd.Flags().BoolVar(&links, "links", false, "Link nodes to their pkg.go.dev pages in DOT (SVG) and HTML output")
+	rootCmd.Flags().StringArrayVar(&aliases, "alias", nil, "Display a module path under a friendly label, as path=Label (repeatable)")
+	rootCmd.Flags().IntVar(&parallel, "parallel", 1, "Number of workers for transitive computations such as --blast-radius on large graphs")
+	rootCmd.Flags().StringVar(&goModFile, "gomod", "", "List go.mod requires that are not direct edges from the main module")
+	rootCmd.Flags().StringVar(&replaceImpact, "replace-impact", "", "List modules reachable only through modules replaced in the given go.mod")
+	rootCmd.Flags().BoolVar(&precompute, "precompute-layout", false, "Position HTML nodes ahead of time so the page opens without running the force simulation")
+	rootCmd.Flags().BoolVar(&flatten, "flatten", false, "Replace the graph with direct edges from the main module to every module it depends on")
+	rootCmd.Flags().StringVar(&teamsFile, "teams", "", "File mapping module paths to owning teams (one \"path team\" pair per line)")
+	rootCmd.Flags().StringVar(&team, "team", "", "Keep only modules owned by this team, with their edges and links from the main module")
+	rootCmd.Flags().BoolVar(&conflicts, "indirect-conflicts", false, "List modules required at more than one version, with the modules requiring each")
+	rootCmd.Flags().IntVar(&top, "top", 0, "Keep only the main module and the N most central modules, for compact diagrams (0 disables)")
+	rootCmd.Flags().BoolVar(&github, "github", false, "Wrap Mermaid output in a ```mermaid fenced block for GitHub markdown")
+	rootCmd.Flags().IntVar(&indent, "indent", 4, "Width of each level of the text tree in characters")
+	rootCmd.Flags().IntVar(&canvasWidth, "width", 1200, "Width of the HTML canvas in pixels")
+	rootCmd.Flags().IntVar(&canvasHeight, "height", 800, "Height of the HTML canvas in pixels")
+	rootCmd.Flags().StringVar(&whyTarget, "target", "", "Module explained by the why format, as path or path@version")
+	rootCmd.Flags().BoolVar(&stableIDs, "stable-ids", false, "Derive HTML node ids from module strings so they stay the same across regenerations")
+	rootCmd.Flags().BoolVar(&hideCommon, "hide-common", false, "Hide common infrastructure modules such as golang.org/x/sys")
+	rootCmd.Flags().StringVar(&commonFile, "common-file", "", "File of module path prefixes hidden by --hide-common, one per line, replacing the built-in list")
+	rootCmd.Flags().StringVar(&viewFile, "view", "", "Apply the filter steps of a JSON view file before any other transform")
+	rootCmd.Flags().StringArrayVar(&excludes, "exclude", nil, "Remove modules under this path prefix and anything only reachable through them (repeatable)")
+	rootCmd.Flags().BoolVar(&summarize, "summarize", false, "Fold text tree nodes with many children into a \"... and M more\" line")
+	rootCmd.Flags().IntVar(&summarizeAfter, "summarize-after", 10, "Number of children shown before --summarize folds the rest")
+	rootCmd.Flags().StringVar(&inputFormat, "input-format", "gomodgraph", "Format of the graph file (gomodgraph, bazel)")
+	rootCmd.Flags().StringVar(&teeFile, "tee", "", "Also write the output to this file, replacing it only once rendering succeeds")
+	rootCmd.Flags().BoolVar(&withHeader, "with-header", false, "Start text, DOT and Mermaid output with a comment giving module and edge counts and the generation time")
+	rootCmd.Flags().BoolVar(&breakCycles, "break-cycles", false, "List edges whose removal makes the graph acyclic (heuristic, not always minimal)")
+	rootCmd.Flags().StringVar(&testDepsFile, "test-deps", "", "File of modules the main module imports only from tests, one per line")
+	rootCmd.Flags().BoolVar(&prodOnly, "prod-only", false, "Keep only modules needed by production code (requires --test-deps)")
+	rootCmd.Flags().BoolVar(&testOnly, "test-only", false, "Keep only modules needed by tests (requires --test-deps)")
+	rootCmd.Flags().BoolVar(&childCounts, "counts", false, "Append the number of direct children to each node of the text tree")
+	rootCmd.Flags().BoolVar(&hideZeroCounts, "hide-zero-counts", false, "Leave leaves unmarked with --counts instead of showing (0)")
+	rootCmd.Flags().BoolVar(&transpose, "transpose", false, "Reverse every edge so arrows point from a module to its dependents")
+	rootCmd.Flags().BoolVar(&expandAll, "expand-all", false, "Repeat a shared module's subtree under every parent in the text tree")
+	rootCmd.Flags().IntVar(&expandDepth, "expand-depth", 10, "Deepest level expanded by --expand-all")
+	rootCmd.Flags().StringVar(&centrality, "centrality", "in", "Edges counted to rank modules for --top and size HTML nodes (in, out, both)")
+	rootCmd.Flags().IntVar(&nearLeaves, "near-leaves", 0, "Keep only modules within N hops above a leaf module, showing the bottom of the tree (0 disables)")
+	rootCmd.Flags().BoolVar(&cyclesJSON, "cycles-json", false, "Write the graph's cycles as a JSON array of module string arrays, in canonical order")
+	rootCmd.Flags().BoolVar(&clipboard, "clipboard", false, "Copy the output to the system clipboard (pbcopy, clip.exe, wl-copy, xclip or xsel) instead of printing it")
+	rootCmd.Flags().BoolVar(&balance, "balance", false, "Report the branching factor, modules per depth and skew of the subtrees under the main module")
+	rootCmd.Flags().BoolVar(&cssClasses, "css-classes", false, "Add organization and depth CSS classes to HTML nodes and links for custom styling")
+	rootCmd.Flags().BoolVar(&focusFragment, "focus-fragment", false, "Make the HTML page select and center the module named in a #focus=<module> URL fragment")
+	rootCmd.Flags().BoolVar(&edgesByDepth, "edges-by-depth", false, "Report how many edges leave modules at each depth from the main module")
+	rootCmd.Flags().Uint32Var(&layoutSeed, "seed", 0, "Seed the HTML force layout so regenerating the page gives the same layout (0 keeps D3's default)")
+	rootCmd.Flags().BoolVar(&splitByRoot, "split-by-root-child", false, "Render one file per direct dependency of the main module into --output-dir, each with that dependency's subtree")
+	rootCmd.Flags().BoolVar(&selfLoops, "self-loops", false, "List edges from a module path to itself, such as foo@v1 -> foo@v2 introduced by a replace")
+	rootCmd.Flags().Int64Var(&maxInputBytes, "max-input-bytes", 0, "Fail if the graph file is larger than N bytes, bounding memory for untrusted input (0 disables)")
+	rootCmd.Flags().DurationVar(&inputTimeout, "input-timeout", 0, "Fail if the graph file is not read within this duration, e.g. from a stalled pipe (0 disables)")
+	rootCmd.Flags().BoolVar(&ageHistogram, "age-histogram", false, "Bucket dependencies by version age (<6mo, 6-12mo, >1y, unknown) from pseudo-version dates or --dates")
+	rootCmd.Flags().StringVar(&datesFile, "dates", "", "File mapping module paths or path@version to release dates (one \"module YYYY-MM-DD\" pair per line)")
+	rootCmd.Flags().BoolVar(&relaxBackEdges, "relax-back-edges", false, "In DOT output, set constraint=false on edges pointing back towards the main module, such as cycle edges")
+	rootCmd.Flags().StringVar(&changedFile, "changed", "", "File of changed module paths, one per line (e.g. from a go.mod diff); render only them and their direct neighbors")
+	rootCmd.Flags().StringArrayVar(&options, "option", nil, "Pass a renderer-specific setting as key=value, e.g. rankdir=TB for dot or charge=-500 for html (repeatable)")
+	rootCmd.Flags().BoolVar(&orphanVersions, "orphan-versions", false, "List module versions still required although a higher version of the same path is selected, with their requesters")
+	rootCmd.Flags().Float64Var(&rankSep, "ranksep", 0, "In DOT output, set the spacing between ranks in inches")
+	rootCmd.Flags().Float64Var(&nodeSep, "nodesep", 0, "In DOT output, set the spacing between nodes in a rank in inches")
+	rootCmd.Flags().BoolVar(&dominators, "dominators", false, "List modules whose removal would cut off others, with how many, from the dominator tree rooted at the main module")
+	rootCmd.Flags().StringVar(&vulnsFile, "vulns", "", "File of govulncheck -json output; vulnerable modules are drawn in red with their IDs in DOT and HTML tooltips")
+	rootCmd.Flags().IntVar(&maxEdges, "max-edges", 0, "Render at most this many edges, keeping those closest to the main module and noting how many were dropped on stderr and in a text, DOT or Mermaid comment (0 for no limit)")
+	rootCmd.Flags().BoolVar(&majorSpread, "major-spread", false, "List module paths present at more than one major version")
+	rootCmd.Flags().BoolVar(&outdated, "outdated", false, "List likely upgrade candidates (v0.x or +incompatible versions; heuristic, no network)")
 }
@@ -0,0 +1,790 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/scottbrown/tangled"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const testGraph = `github.com/example/main github.com/dep1@v1.0.0
+github.com/example/main github.com/dep2@v2.0.0
+github.com/dep1@v1.0.0 github.com/subdep@v1.0.0
+`
+
+// writeGraphFile writes graph content to a temporary file and returns its path
+func writeGraphFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "deps.graph")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write graph file: %v", err)
+	}
+	return path
+}
+
+// executeRoot runs the root command with args, resetting the flags of every
+// command to their defaults first, and returns what was written to stdout
+func executeRoot(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	for _, c := range append([]*cobra.Command{rootCmd}, rootCmd.Commands()...) {
+		c.Flags().VisitAll(func(f *pflag.Flag) {
+			if slice, ok := f.Value.(pflag.SliceValue); ok {
+				_ = slice.Replace(nil)
+			} else {
+				_ = f.Value.Set(f.DefValue)
+			}
+			f.Changed = false
+		})
+	}
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs(args)
+
+	err := rootCmd.Execute()
+	return stdout.String(), err
+}
+
+func TestPrintRoot(t *testing.T) {
+	path := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--print-root", path)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output != "github.com/example/main\n" {
+		t.Errorf("--print-root output = %q, want %q", output, "github.com/example/main\n")
+	}
+}
+
+func TestParseEgo(t *testing.T) {
+	tests := []struct {
+		input   string
+		module  string
+		radius  int
+		wantErr bool
+	}{
+		{input: "github.com/foo@v1.0.0", module: "github.com/foo@v1.0.0", radius: 1},
+		{input: "github.com/foo@v1.0.0:3", module: "github.com/foo@v1.0.0", radius: 3},
+		{input: "github.com/foo:0", module: "github.com/foo", radius: 0},
+		{input: "github.com/foo:-1", wantErr: true},
+		{input: "github.com/foo:x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			module, radius, err := parseEgo(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEgo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (module != tt.module || radius != tt.radius) {
+				t.Errorf("parseEgo() = %q, %d, want %q, %d", module, radius, tt.module, tt.radius)
+			}
+		})
+	}
+}
+
+func TestDenyLicense(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	licensesPath := filepath.Join(t.TempDir(), "licenses.txt")
+	if err := os.WriteFile(licensesPath, []byte("github.com/subdep GPL-3.0\n"), 0o600); err != nil {
+		t.Fatalf("failed to write licenses file: %v", err)
+	}
+
+	if _, err := executeRoot(t, "--licenses", licensesPath, "--deny-license", "GPL-3.0", graphPath); err == nil {
+		t.Error("Execute() should fail when a module carries a denied license")
+	}
+	if _, err := executeRoot(t, "--licenses", licensesPath, "--deny-license", "AGPL-3.0", graphPath); err != nil {
+		t.Errorf("Execute() error = %v, want nil", err)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	baselinePath := filepath.Join(t.TempDir(), "baseline.txt")
+	if err := os.WriteFile(baselinePath, []byte("github.com/dep1 v1.1.0\ngithub.com/dep2 v2.0.0\n"), 0o600); err != nil {
+		t.Fatalf("failed to write baseline file: %v", err)
+	}
+
+	_, err := executeRoot(t, "--baseline", baselinePath, graphPath)
+	if err == nil {
+		t.Fatal("Execute() should fail when versions drift from the baseline")
+	}
+	if !strings.Contains(err.Error(), "1 modules differ") {
+		t.Errorf("error = %v, want a count of drifted modules", err)
+	}
+}
+
+func TestMaxDeps(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	if _, err := executeRoot(t, "--max-deps", "2", graphPath); err == nil {
+		t.Error("Execute() should fail when the graph exceeds the dependency budget")
+	}
+	if _, err := executeRoot(t, "--max-deps", "3", graphPath); err != nil {
+		t.Errorf("Execute() error = %v, want nil", err)
+	}
+}
+
+func TestBlastRadius(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--blast-radius", "1", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output != "2 github.com/subdep@v1.0.0\n" {
+		t.Errorf("output = %q, want the most depended-on module", output)
+	}
+}
+
+func TestFormatAll(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	outDir := filepath.Join(t.TempDir(), "out")
+
+	if _, err := executeRoot(t, "-f", "all", "--output-dir", outDir, graphPath); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatalf("failed to read output directory: %v", err)
+	}
+	base := strings.TrimSuffix(filepath.Base(graphPath), filepath.Ext(graphPath))
+	rendered := 0
+	for _, f := range tangled.Formats() {
+		// Binary formats and, without --target, targeted formats are skipped
+		if f.Binary || f.Target {
+			continue
+		}
+		rendered++
+		if _, err := os.Stat(filepath.Join(outDir, base+f.Suffix)); err != nil {
+			t.Errorf("missing %s output: %v", f.Names[0], err)
+		}
+	}
+	if len(entries) != rendered {
+		t.Errorf("output files = %d, want one per format (%d)", len(entries), rendered)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, base+".pb")); err == nil {
+		t.Error("protobuf output should be skipped by --format all")
+	}
+
+	if _, err := executeRoot(t, "-f", "all", graphPath); err == nil {
+		t.Error("Execute() should require --output-dir with --format all")
+	}
+}
+
+func TestParseAliases(t *testing.T) {
+	aliasMap, err := parseAliases([]string{"github.com/mycorp/internal-foo=Foo Service", "github.com/bar=Bar=Baz"})
+	if err != nil {
+		t.Fatalf("parseAliases() error = %v", err)
+	}
+	if aliasMap["github.com/mycorp/internal-foo"] != "Foo Service" || aliasMap["github.com/bar"] != "Bar=Baz" {
+		t.Errorf("parseAliases() = %v", aliasMap)
+	}
+
+	for _, value := range []string{"github.com/foo", "=Foo", "github.com/foo="} {
+		if _, err := parseAliases([]string{value}); err == nil {
+			t.Errorf("parseAliases(%q) should fail", value)
+		}
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	optionMap, err := parseOptions([]string{"rankdir=LR", "charge=-500", "rankdir=TB"})
+	if err != nil {
+		t.Fatalf("parseOptions() error = %v", err)
+	}
+	if want := map[string]string{"rankdir": "TB", "charge": "-500"}; !reflect.DeepEqual(optionMap, want) {
+		t.Errorf("parseOptions() = %v, want %v", optionMap, want)
+	}
+
+	for _, value := range []string{"rankdir", "=TB", "rankdir="} {
+		if _, err := parseOptions([]string{value}); err == nil {
+			t.Errorf("parseOptions(%q) should fail", value)
+		}
+	}
+}
+
+func TestGoModUnusedRequires(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	goModPath := filepath.Join(t.TempDir(), "go.mod")
+	goMod := "module github.com/example/main\n\nrequire (\n\tgithub.com/dep1 v1.0.0\n\tgithub.com/stale v0.3.0\n)\n"
+	if err := os.WriteFile(goModPath, []byte(goMod), 0o600); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	output, err := executeRoot(t, "--gomod", goModPath, graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output != "github.com/stale@v0.3.0\n" {
+		t.Errorf("output = %q, want only the stale require", output)
+	}
+}
+
+func TestBinaryFormatRequiresOutput(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	if _, err := executeRoot(t, "-f", "protobuf", graphPath); err == nil {
+		t.Error("Execute() should require -o for binary formats")
+	}
+
+	output, err := executeRoot(t, "-f", "protobuf", "-o", "-", "--line-ending", "crlf", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var graph tangled.DependencyGraph
+	if err := graph.UnmarshalProto([]byte(output)); err != nil {
+		t.Errorf("stdout should hold an unmodified protobuf message: %v", err)
+	}
+}
+
+func TestReplaceImpact(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	goModPath := filepath.Join(t.TempDir(), "go.mod")
+	goMod := "module github.com/example/main\n\nreplace github.com/dep1 v1.0.0 => ../dep1\n"
+	if err := os.WriteFile(goModPath, []byte(goMod), 0o600); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	output, err := executeRoot(t, "--replace-impact", goModPath, graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output != "github.com/subdep@v1.0.0\n" {
+		t.Errorf("output = %q, want the module only reachable through dep1", output)
+	}
+}
+
+func TestWhyFormat(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	if _, err := executeRoot(t, "-f", "why", graphPath); err == nil {
+		t.Error("Execute() should require --target for the why format")
+	}
+
+	output, err := executeRoot(t, "-f", "why", "--target", "github.com/subdep", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := "# github.com/subdep\ngithub.com/example/main\ngithub.com/dep1\ngithub.com/subdep\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestHideCommon(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/dep2@v2.0.0 golang.org/x/sys@v0.20.0\n")
+
+	output, err := executeRoot(t, "--hide-common", "-f", "modules", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if strings.Contains(output, "golang.org/x/sys") {
+		t.Errorf("output = %q, want golang.org/x/sys hidden", output)
+	}
+
+	commonPath := filepath.Join(t.TempDir(), "common.txt")
+	if err := os.WriteFile(commonPath, []byte("github.com/dep2\n"), 0o600); err != nil {
+		t.Fatalf("failed to write common file: %v", err)
+	}
+	output, err = executeRoot(t, "--hide-common", "--common-file", commonPath, "-f", "modules", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if strings.Contains(output, "github.com/dep2") || strings.Contains(output, "golang.org/x/sys") {
+		t.Errorf("output = %q, want dep2 and everything behind it hidden", output)
+	}
+}
+
+func TestViewMatchesFlags(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	viewPath := filepath.Join(t.TempDir(), "view.json")
+	if err := os.WriteFile(viewPath, []byte(`{"steps": [{"exclude": ["github.com/dep1"]}]}`), 0o600); err != nil {
+		t.Fatalf("failed to write view file: %v", err)
+	}
+
+	fromView, err := executeRoot(t, "--view", viewPath, graphPath)
+	if err != nil {
+		t.Fatalf("Execute() with --view error = %v", err)
+	}
+	fromFlag, err := executeRoot(t, "--exclude", "github.com/dep1", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() with --exclude error = %v", err)
+	}
+	if fromView != fromFlag {
+		t.Errorf("--view output = %q, want the --exclude output %q", fromView, fromFlag)
+	}
+	if strings.Contains(fromView, "subdep") {
+		t.Errorf("output = %q, want modules behind dep1 removed", fromView)
+	}
+}
+
+func TestMajorSpread(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/dep2@v2.0.0 github.com/dep1/v3@v3.0.0\n")
+
+	output, err := executeRoot(t, "--major-spread", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output != "github.com/dep1: v1, v3\n" {
+		t.Errorf("output = %q, want dep1 listed at both majors", output)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--summarize", "--summarize-after", "1", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, "... and 1 more") || strings.Contains(output, "dep2") {
+		t.Errorf("output = %q, want dep2 folded into a summary line", output)
+	}
+}
+
+func TestBazelInputFormat(t *testing.T) {
+	graphPath := writeGraphFile(t, "github.com/example/main -> github.com/dep1@v1.0.0\ngithub.com/dep1@v1.0.0 -> github.com/subdep@v1.0.0\n")
+
+	output, err := executeRoot(t, "--input-format", "bazel", "-f", "modules", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "github.com/dep1@v1.0.0\ngithub.com/example/main\ngithub.com/subdep@v1.0.0\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+
+	if _, err := executeRoot(t, "--input-format", "maven", graphPath); err == nil {
+		t.Error("Execute() should reject an unknown input format")
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--with-header", "-f", "dot", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	firstLine, _, _ := strings.Cut(output, "\n")
+	if !strings.HasPrefix(firstLine, "// modules: 4, edges: 3, generated: ") {
+		t.Errorf("first line = %q, want a // comment with the counts", firstLine)
+	}
+}
+
+func TestBreakCycles(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/subdep@v1.0.0 github.com/dep1@v1.0.0\n")
+
+	output, err := executeRoot(t, "--break-cycles", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if strings.Count(output, "\n") != 1 || !strings.Contains(output, " -> ") {
+		t.Errorf("output = %q, want a single edge to cut", output)
+	}
+}
+
+func TestProdOnly(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	testDepsPath := filepath.Join(t.TempDir(), "test-deps.txt")
+	if err := os.WriteFile(testDepsPath, []byte("github.com/dep1\n"), 0o600); err != nil {
+		t.Fatalf("failed to write test dependencies file: %v", err)
+	}
+
+	output, err := executeRoot(t, "--test-deps", testDepsPath, "--prod-only", "-f", "modules", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "github.com/dep2@v2.0.0\ngithub.com/example/main\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+
+	if _, err := executeRoot(t, "--prod-only", graphPath); err == nil {
+		t.Error("Execute() should require --test-deps with --prod-only")
+	}
+}
+
+func TestChildCounts(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--counts", "--hide-zero-counts", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.HasPrefix(output, "github.com/example/main (2)\n") || strings.Contains(output, "(0)") {
+		t.Errorf("output = %q, want the root marked (2) and leaves unmarked", output)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--transpose", "-f", "csv-edges", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, "github.com/subdep@v1.0.0,github.com/dep1@v1.0.0") {
+		t.Errorf("output = %q, want the subdep -> dep1 edge reversed", output)
+	}
+}
+
+func TestExpandAll(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/dep2@v2.0.0 github.com/subdep@v1.0.0\ngithub.com/subdep@v1.0.0 github.com/leaf@v1.0.0\n")
+
+	output, err := executeRoot(t, "--expand-all", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := strings.Count(output, "github.com/leaf@v1.0.0"); got != 2 {
+		t.Errorf("output = %q, want the shared child of subdep under both parents", output)
+	}
+}
+
+func TestCentralityFlag(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	if _, err := executeRoot(t, "--centrality", "sideways", graphPath); err == nil || !strings.Contains(err.Error(), "unsupported centrality") {
+		t.Errorf("Execute() error = %v, want an unsupported centrality error", err)
+	}
+
+	output, err := executeRoot(t, "--centrality", "out", "--top", "1", "-f", "csv-edges", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if strings.Contains(output, "github.com/subdep@v1.0.0") {
+		t.Errorf("output = %q, want --top 1 with out to drop the leaf subdep", output)
+	}
+
+	// Without the flag, --top and HTML sizes count incoming edges
+	graphPath = writeGraphFile(t, testGraph+"github.com/dep2@v2.0.0 github.com/subdep@v1.0.0\n")
+	output, err = executeRoot(t, "--top", "2", "-f", "csv-edges", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, "github.com/dep1@v1.0.0,github.com/subdep@v1.0.0") || strings.Contains(output, "dep2") {
+		t.Errorf("output = %q, want --top 2 to keep subdep and then dep1 by in-degree", output)
+	}
+	output, err = executeRoot(t, "-f", "html", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, `"name": "github.com/subdep@v1.0.0", "group": 1, "r": 8.8`) {
+		t.Errorf("html should size subdep by its two incoming edges, got:\n%s", output)
+	}
+}
+
+func TestNearLeaves(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/subdep@v1.0.0 github.com/leaf@v1.0.0\n")
+
+	output, err := executeRoot(t, "--near-leaves", "1", "-f", "csv-edges", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, edge := range []string{"github.com/subdep@v1.0.0,github.com/leaf@v1.0.0", "github.com/example/main,github.com/dep2@v2.0.0"} {
+		if !strings.Contains(output, edge) {
+			t.Errorf("output = %q, want the leaf edge %s", output, edge)
+		}
+	}
+	if strings.Contains(output, "github.com/dep1@v1.0.0") {
+		t.Errorf("output = %q, want dep1 dropped as it is two hops above the leaf", output)
+	}
+}
+
+func TestCyclesJSON(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/subdep@v1.0.0 github.com/dep1@v1.0.0\n")
+
+	output, err := executeRoot(t, "--cycles-json", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var cycles [][]string
+	if err := json.Unmarshal([]byte(output), &cycles); err != nil {
+		t.Fatalf("output %q should unmarshal into a slice of slices: %v", output, err)
+	}
+	want := [][]string{{"github.com/dep1@v1.0.0", "github.com/subdep@v1.0.0"}}
+	if !reflect.DeepEqual(cycles, want) {
+		t.Errorf("cycles = %v, want %v", cycles, want)
+	}
+
+	acyclicPath := writeGraphFile(t, testGraph)
+	if output, err := executeRoot(t, "--cycles-json", acyclicPath); err != nil || strings.TrimSpace(output) != "[]" {
+		t.Errorf("acyclic output = %q, %v, want an empty JSON array", output, err)
+	}
+}
+
+func TestBalance(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--balance", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := "branching factor: 1.50\ndepth 0: 1\ndepth 1: 2\ndepth 2: 1\nskew: 0.33\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestCSSClasses(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--css-classes", "-f", "html", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, `"classes": "node-github-com-dep1 depth-1"`) {
+		t.Error("HTML output should carry CSS classes on nodes with --css-classes")
+	}
+}
+
+func TestEdgesByDepth(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--edges-by-depth", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "depth 0: 2\ndepth 1: 1\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--seed", "7", "-f", "html", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, "let layoutSeed = 7;") {
+		t.Error("HTML output should carry the --seed value")
+	}
+}
+
+func TestSplitByRootChild(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	outDir := filepath.Join(t.TempDir(), "out")
+
+	if _, err := executeRoot(t, "--split-by-root-child", "--output-dir", outDir, "-f", "csv-edges", graphPath); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatalf("failed to read output directory: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("output files = %d, want one per direct dependency (2)", len(entries))
+	}
+
+	base := strings.TrimSuffix(filepath.Base(graphPath), filepath.Ext(graphPath))
+	content, err := os.ReadFile(filepath.Join(outDir, base+".github.com_dep1_v1.0.0.edges.csv")) // #nosec G304 -- test file in a temporary directory
+	if err != nil {
+		t.Fatalf("missing dep1 subtree: %v", err)
+	}
+	if !strings.Contains(string(content), "github.com/dep1@v1.0.0,github.com/subdep@v1.0.0") || strings.Contains(string(content), "dep2") {
+		t.Errorf("dep1 subtree = %q, want only dep1 and what it reaches", content)
+	}
+
+	if _, err := executeRoot(t, "--split-by-root-child", graphPath); err == nil {
+		t.Error("Execute() should require --output-dir with --split-by-root-child")
+	}
+
+	for _, flag := range [][]string{{"--clipboard"}, {"--tee", filepath.Join(outDir, "tee.txt")}, {"--balance"}, {"--blast-radius", "1"}} {
+		args := append([]string{"--split-by-root-child", "--output-dir", outDir}, flag...)
+		if _, err := executeRoot(t, append(args, graphPath)...); err == nil || !strings.Contains(err.Error(), flag[0]) {
+			t.Errorf("Execute() error = %v, want %s rejected with --split-by-root-child", err, flag[0])
+		}
+	}
+}
+
+func TestSelfLoops(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/dep1@v1.0.0 github.com/dep1@v1.1.0\n")
+
+	output, err := executeRoot(t, "--self-loops", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "github.com/dep1@v1.0.0 -> github.com/dep1@v1.1.0\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestAgeHistogram(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	datesPath := filepath.Join(t.TempDir(), "dates.txt")
+	if err := os.WriteFile(datesPath, []byte("github.com/dep1 2019-06-01\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeRoot(t, "--age-histogram", "--dates", datesPath, graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := "<6mo: 0\n6-12mo: 0\n>1y: 1\n  github.com/dep1@v1.0.0\nunknown: 2\n  github.com/dep2@v2.0.0\n  github.com/subdep@v1.0.0\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestRelaxBackEdges(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/subdep@v1.0.0 github.com/dep1@v1.0.0\n")
+
+	output, err := executeRoot(t, "--relax-back-edges", "-f", "dot", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, `"github_com_subdep_v1_0_0" -> "github_com_dep1_v1_0_0" [constraint=false];`) {
+		t.Errorf("cycle edge should be relaxed, got:\n%s", output)
+	}
+}
+
+func TestChanged(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	changedPath := filepath.Join(t.TempDir(), "changed.txt")
+	if err := os.WriteFile(changedPath, []byte("# bumped in go.mod\ngithub.com/subdep\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeRoot(t, "--changed", changedPath, "-f", "csv-edges", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "from,to\ngithub.com/dep1@v1.0.0,github.com/subdep@v1.0.0\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestOption(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--option", "rankdir=TB", "-f", "dot", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, "rankdir=TB;") || strings.Contains(output, "rankdir=LR;") {
+		t.Errorf("dot output should use the rankdir option, got:\n%s", output)
+	}
+
+	for _, args := range [][]string{
+		{"--option", "rankdri=TB", "-f", "dot"},
+		{"--option", "rankdir=TB", "-f", "html"},
+		{"--option", "charge=-500", "-f", "text"},
+		{"--option", "rankdri=TB", "-f", "all", "--output-dir", t.TempDir()},
+	} {
+		if _, err := executeRoot(t, append(args, graphPath)...); err == nil || !strings.Contains(err.Error(), "unknown option") {
+			t.Errorf("Execute(%v) error = %v, want an unknown option error", args, err)
+		}
+	}
+	if _, err := executeRoot(t, "--option", "charge=-500", "-f", "all", "--output-dir", t.TempDir(), graphPath); err != nil {
+		t.Errorf("Execute() error = %v, want options of any format accepted with --format all", err)
+	}
+}
+
+func TestOrphanVersions(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/example/main github.com/subdep@v1.2.0\n")
+
+	output, err := executeRoot(t, "--orphan-versions", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "github.com/subdep@v1.0.0: selected v1.2.0, still required by github.com/dep1@v1.0.0\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestRankSep(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--ranksep", "1.2", "-f", "dot", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, "ranksep=1.2;") {
+		t.Errorf("dot output should carry the --ranksep value, got:\n%s", output)
+	}
+
+	for _, args := range [][]string{{"--ranksep", "0"}, {"--nodesep", "-1"}, {"--ranksep", "NaN"}} {
+		if _, err := executeRoot(t, append(args, graphPath)...); err == nil {
+			t.Errorf("Execute(%v) should reject a non-positive spacing", args)
+		}
+	}
+}
+
+func TestDominators(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph+"github.com/subdep@v1.0.0 github.com/leaf@v1.0.0\ngithub.com/dep2@v2.0.0 github.com/leaf@v1.0.0\n")
+
+	output, err := executeRoot(t, "--dominators", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := "github.com/dep1@v1.0.0: 1\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestVulns(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	vulnsPath := filepath.Join(t.TempDir(), "vulns.json")
+	finding := `{"finding": {"osv": "GO-2023-0001", "trace": [{"module": "github.com/subdep", "version": "v1.0.0"}]}}` + "\n"
+	if err := os.WriteFile(vulnsPath, []byte(finding), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeRoot(t, "--vulns", vulnsPath, "-f", "dot", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(output, `tooltip="github.com/subdep@v1.0.0: GO-2023-0001"`) {
+		t.Errorf("vulnerable module should be marked in DOT output, got:\n%s", output)
+	}
+}
+
+func TestMaxEdges(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+
+	for _, n := range []string{"1", "2"} {
+		output, err := executeRoot(t, "--max-edges", n, "-f", "csv-edges", graphPath)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		rows := strings.Split(strings.TrimSpace(output), "\n")[1:]
+		if want, _ := strconv.Atoi(n); len(rows) > want {
+			t.Errorf("--max-edges %s rendered %d edges: %q", n, len(rows), rows)
+		}
+		for _, row := range rows {
+			if !strings.HasPrefix(row, "github.com/example/main,") {
+				t.Errorf("--max-edges %s kept %q over an edge from the main module", n, row)
+			}
+		}
+	}
+
+	// The rendered output records the truncation, not just stderr
+	output, err := executeRoot(t, "--max-edges", "1", "-f", "dot", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.HasPrefix(output, "// dropped edges: 2\n") {
+		t.Errorf("dot output should start with the dropped edge count, got:\n%s", output)
+	}
+	output, err = executeRoot(t, "--max-edges", "1", "--with-header", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if first, _, _ := strings.Cut(output, "\n"); !strings.HasPrefix(first, "# modules: ") || !strings.HasSuffix(first, ", dropped edges: 2") {
+		t.Errorf("header = %q, want it to end with the dropped edge count", first)
+	}
+}
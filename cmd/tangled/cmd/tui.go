@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/scottbrown/tangled"
+	"github.com/spf13/cobra"
+)
+
+// tuiCmd explores a dependency graph as an interactive, collapsible tree
+var tuiCmd = &cobra.Command{
+	Use:   "tui [graph-file]",
+	Short: "Explore a dependency graph interactively in the terminal",
+	Long: `tui shows the dependency graph as an expandable tree.
+
+Keys:
+  up/down, k/j     move the cursor
+  right/l, left/h  expand or collapse the selected module
+  space, enter     toggle the selected module
+  /                filter modules by name (enter to keep, esc to clear)
+  q, ctrl+c        quit`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTUI,
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	graph, err := tangled.ParseGraphFromFile(args[0])
+	if err != nil {
+		return withExitCode(ExitParse, fmt.Errorf("failed to parse graph file: %w", err))
+	}
+
+	program := tea.NewProgram(newTUIModel(graph), tea.WithAltScreen(), tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.OutOrStdout()))
+	_, err = program.Run()
+	return err
+}
+
+// tuiRow is a single visible line of the tree
+type tuiRow struct {
+	id          string // path of module strings from the root, unique per row
+	module      string
+	depth       int
+	hasChildren bool
+	cycle       bool // module already appears on the path above it
+}
+
+// tuiModel is the bubbletea model for the tui command
+type tuiModel struct {
+	tree      map[string][]string
+	root      string
+	expanded  map[string]bool // keyed by row id
+	rows      []tuiRow
+	cursor    int
+	offset    int // index of the first row on screen
+	height    int // rows available for the tree; zero until the terminal size is known
+	filter    string
+	searching bool
+}
+
+// newTUIModel creates a model showing the main module with its direct
+// dependencies expanded
+func newTUIModel(graph *tangled.DependencyGraph) *tuiModel {
+	root := graph.MainModule.String()
+	m := &tuiModel{
+		tree:     graph.GetTree(),
+		root:     root,
+		expanded: map[string]bool{root: true},
+	}
+	m.refresh()
+	return m
+}
+
+// Init implements tea.Model
+func (m *tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height - 2 // leave room for the status line
+		m.scroll()
+	case tea.KeyMsg:
+		if m.searching {
+			m.updateSearch(msg)
+			return m, nil
+		}
+		return m, m.updateTree(msg)
+	}
+	return m, nil
+}
+
+// updateSearch edits the filter while the search prompt is open
+func (m *tuiModel) updateSearch(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyEsc:
+		m.searching = false
+		m.filter = ""
+	case tea.KeyBackspace:
+		if m.filter != "" {
+			m.filter = m.filter[:len(m.filter)-1]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	default:
+		return
+	}
+	m.cursor = 0
+	m.refresh()
+}
+
+// updateTree handles navigation keys, returning a command to quit if requested
+func (m *tuiModel) updateTree(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+	case "right", "l":
+		m.setExpanded(true)
+	case "left", "h":
+		m.setExpanded(false)
+	case " ", "enter":
+		if row, ok := m.selected(); ok {
+			m.setExpanded(!m.expanded[row.id])
+		}
+	case "/":
+		m.searching = true
+	case "esc":
+		m.filter = ""
+		m.refresh()
+	}
+	m.scroll()
+	return nil
+}
+
+// selected returns the row under the cursor
+func (m *tuiModel) selected() (tuiRow, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return tuiRow{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// setExpanded expands or collapses the selected row and rebuilds the view
+func (m *tuiModel) setExpanded(expanded bool) {
+	row, ok := m.selected()
+	if !ok || !row.hasChildren || row.cycle {
+		return
+	}
+	if expanded {
+		m.expanded[row.id] = true
+	} else {
+		delete(m.expanded, row.id)
+	}
+	m.refresh()
+}
+
+// refresh rebuilds the visible rows from the expansion state and filter
+func (m *tuiModel) refresh() {
+	m.rows = m.rows[:0]
+	m.appendRows(m.root, m.root, 0, map[string]bool{})
+	if m.filter != "" {
+		m.rows = filterRows(m.rows, m.filter)
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = max(len(m.rows)-1, 0)
+	}
+	m.scroll()
+}
+
+func (m *tuiModel) appendRows(id, module string, depth int, onPath map[string]bool) {
+	children := m.tree[module]
+	row := tuiRow{id: id, module: module, depth: depth, hasChildren: len(children) > 0, cycle: onPath[module]}
+	m.rows = append(m.rows, row)
+	if row.cycle || !m.expanded[id] {
+		return
+	}
+
+	onPath[module] = true
+	for _, child := range children {
+		m.appendRows(id+"\x00"+child, child, depth+1, onPath)
+	}
+	delete(onPath, module)
+}
+
+// filterRows keeps rows whose module contains the filter text, along with
+// their ancestors so matches stay in context
+func filterRows(rows []tuiRow, filter string) []tuiRow {
+	filter = strings.ToLower(filter)
+	keep := make([]bool, len(rows))
+	matchDepth := -1 // shallowest depth below which a later match was seen
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		if strings.Contains(strings.ToLower(row.module), filter) {
+			keep[i] = true
+			if matchDepth == -1 || row.depth < matchDepth {
+				matchDepth = row.depth
+			}
+		} else if matchDepth != -1 && row.depth < matchDepth {
+			keep[i] = true
+			matchDepth = row.depth
+		}
+	}
+
+	var filtered []tuiRow
+	for i, row := range rows {
+		if keep[i] {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered
+}
+
+// scroll keeps the cursor within the visible window
+func (m *tuiModel) scroll() {
+	if m.height <= 0 {
+		return
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+}
+
+// View implements tea.Model
+func (m *tuiModel) View() string {
+	var sb strings.Builder
+
+	end := len(m.rows)
+	if m.height > 0 {
+		end = min(end, m.offset+m.height)
+	}
+	for i := m.offset; i < end; i++ {
+		row := m.rows[i]
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		marker := "  "
+		switch {
+		case row.cycle:
+			marker = "↻ "
+		case row.hasChildren && m.expanded[row.id]:
+			marker = "▾ "
+		case row.hasChildren:
+			marker = "▸ "
+		}
+		fmt.Fprintf(&sb, "%s%s%s%s\n", cursor, strings.Repeat("  ", row.depth), marker, row.module)
+	}
+
+	switch {
+	case m.searching:
+		fmt.Fprintf(&sb, "/%s", m.filter)
+	case m.filter != "":
+		fmt.Fprintf(&sb, "filter: %s (esc to clear)", m.filter)
+	default:
+		sb.WriteString("↑/↓ move  ←/→ collapse/expand  / search  q quit")
+	}
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/scottbrown/tangled"
+)
+
+func newTestTUIModel(t *testing.T) *tuiModel {
+	t.Helper()
+
+	graph, err := tangled.ParseGraph(strings.NewReader(testGraph))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+	return newTUIModel(graph)
+}
+
+// rowModules returns the module of every visible row
+func rowModules(m *tuiModel) []string {
+	modules := make([]string, len(m.rows))
+	for i, row := range m.rows {
+		modules[i] = row.module
+	}
+	return modules
+}
+
+func TestTUIModel_ExpandCollapse(t *testing.T) {
+	m := newTestTUIModel(t)
+	if len(m.rows) != 3 {
+		t.Fatalf("initial rows = %v, want the main module and its direct dependencies", rowModules(m))
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if got := rowModules(m); len(got) != 4 || got[2] != "github.com/subdep@v1.0.0" {
+		t.Errorf("rows after expanding dep1 = %v, want subdep beneath it", got)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if got := rowModules(m); len(got) != 3 {
+		t.Errorf("rows after collapsing dep1 = %v, want subdep hidden", got)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if got := rowModules(m); len(got) != 1 {
+		t.Errorf("rows after toggling the root = %v, want only the root", got)
+	}
+}
+
+func TestTUIModel_Search(t *testing.T) {
+	m := newTestTUIModel(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("subdep")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	want := []string{"github.com/example/main", "github.com/dep1@v1.0.0", "github.com/subdep@v1.0.0"}
+	if got := rowModules(m); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("filtered rows = %v, want %v", got, want)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.rows) != 4 {
+		t.Errorf("rows after clearing the filter = %v", rowModules(m))
+	}
+}
+
+func TestTUIModel_Quit(t *testing.T) {
+	m := newTestTUIModel(t)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd == nil {
+		t.Error("q should return a quit command")
+	}
+}
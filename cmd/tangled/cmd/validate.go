@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scottbrown/tangled"
+	"github.com/spf13/cobra"
+)
+
+var fatalIssues []string
+
+// validateCmd checks a graph file for structural issues without rendering it
+var validateCmd = &cobra.Command{
+	Use:   "validate [graph-file]",
+	Short: "Check a dependency graph for structural issues",
+	Long: `validate parses a graph file and reports cycles, extra roots, duplicate
+edges and modules unreachable from the main module.
+
+Issues are reported as warnings unless their kind is listed with --fatal,
+in which case the command exits non-zero: with code 5 when any fatal issue
+is a cycle and code 4 otherwise. A graph that fails to parse is
+always an error.
+
+Example usage:
+  tangled validate deps.graph
+  tangled validate --fatal cycle,duplicate-edge deps.graph`,
+	Args: cobra.ExactArgs(1),
+	RunE: runValidate,
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	fatal := make(map[tangled.IssueKind]bool)
+	for _, name := range fatalIssues {
+		kind, err := parseIssueKind(name)
+		if err != nil {
+			return err
+		}
+		fatal[kind] = true
+	}
+
+	graph, err := tangled.ParseGraphFromFile(args[0])
+	if err != nil {
+		return withExitCode(ExitParse, fmt.Errorf("failed to parse graph file: %w", err))
+	}
+
+	issues := graph.Validate()
+	fatalCount := 0
+	code := ExitPolicy
+	for _, issue := range issues {
+		if fatal[issue.Kind] {
+			fatalCount++
+			if issue.Kind == tangled.IssueCycle {
+				code = ExitCycles
+			}
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), issue.String())
+	}
+
+	if fatalCount > 0 {
+		return withExitCode(code, fmt.Errorf("%d fatal issues found", fatalCount))
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "ok")
+	}
+	return nil
+}
+
+// parseIssueKind resolves an issue kind name, case-insensitively
+func parseIssueKind(name string) (tangled.IssueKind, error) {
+	names := make([]string, len(tangled.IssueKinds))
+	for i, kind := range tangled.IssueKinds {
+		if strings.EqualFold(name, string(kind)) {
+			return kind, nil
+		}
+		names[i] = string(kind)
+	}
+	return "", fmt.Errorf("unknown issue kind: %s (supported: %s)", name, strings.Join(names, ", "))
+}
+
+func init() {
+	validateCmd.Flags().StringSliceVar(&fatalIssues, "fatal", nil, "Issue kinds that fail validation (cycle, multiple-roots, duplicate-edge, unreachable)")
+	rootCmd.AddCommand(validateCmd)
+}
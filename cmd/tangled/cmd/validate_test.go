@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("clean graph", func(t *testing.T) {
+		output, err := executeRoot(t, "validate", "--fatal", "cycle,unreachable", writeGraphFile(t, testGraph))
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if output != "ok\n" {
+			t.Errorf("output = %q, want %q", output, "ok\n")
+		}
+	})
+
+	t.Run("malformed graph", func(t *testing.T) {
+		if _, err := executeRoot(t, "validate", writeGraphFile(t, "github.com/example/main\n")); err == nil {
+			t.Error("Execute() should fail for a malformed graph")
+		}
+	})
+
+	cyclic := testGraph + "github.com/subdep@v1.0.0 github.com/dep1@v1.0.0\n"
+
+	t.Run("warning only", func(t *testing.T) {
+		output, err := executeRoot(t, "validate", writeGraphFile(t, cyclic))
+		if err != nil {
+			t.Fatalf("Execute() error = %v, want issues reported as warnings", err)
+		}
+		if !strings.HasPrefix(output, "cycle: ") {
+			t.Errorf("output = %q, want a cycle issue", output)
+		}
+	})
+
+	t.Run("fatal issue", func(t *testing.T) {
+		if _, err := executeRoot(t, "validate", "--fatal", "cycle", writeGraphFile(t, cyclic)); err == nil {
+			t.Error("Execute() should fail when a fatal issue is found")
+		}
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		if _, err := executeRoot(t, "validate", "--fatal", "bogus", writeGraphFile(t, testGraph)); err == nil {
+			t.Error("Execute() should reject unknown issue kinds")
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// crlfWriter translates LF line endings to CRLF, leaving existing CRLF pairs intact
+type crlfWriter struct {
+	w      io.Writer
+	lastCR bool
+}
+
+func (c *crlfWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	buf.Grow(len(p) + bytes.Count(p, []byte("\n")))
+
+	for _, b := range p {
+		if b == '\n' && !c.lastCR {
+			buf.WriteByte('\r')
+		}
+		buf.WriteByte(b)
+		c.lastCR = b == '\r'
+	}
+
+	if _, err := c.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// lineEndingWrapper returns a function that wraps a writer to emit the
+// requested line ending (lf or crlf)
+func lineEndingWrapper(lineEnding string) (func(io.Writer) io.Writer, error) {
+	switch strings.ToLower(lineEnding) {
+	case "lf":
+		return func(w io.Writer) io.Writer { return w }, nil
+	case "crlf":
+		return func(w io.Writer) io.Writer { return &crlfWriter{w: w} }, nil
+	default:
+		return nil, fmt.Errorf("unsupported line ending: %s (supported: lf, crlf)", lineEnding)
+	}
+}
+
+// atomicFile writes to a temporary file beside its destination and only
+// replaces the destination on commit, so readers never see partial output
+type atomicFile struct {
+	*os.File
+	path string
+}
+
+// createAtomic starts an atomic write to path
+func createAtomic(path string) (*atomicFile, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &atomicFile{File: file, path: path}, nil
+}
+
+// commit closes the temporary file and moves it into place
+func (f *atomicFile) commit() error {
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), f.path); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// abort discards the temporary file, leaving the destination untouched
+func (f *atomicFile) abort() {
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+}
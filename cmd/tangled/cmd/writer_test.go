@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCRLFWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := &crlfWriter{w: &buf}
+
+	// Split a CRLF pair across writes to check state is carried over
+	for _, chunk := range []string{"a\nb\r", "\nc\n"} {
+		n, err := writer.Write([]byte(chunk))
+		if err != nil || n != len(chunk) {
+			t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(chunk))
+		}
+	}
+
+	if buf.String() != "a\r\nb\r\nc\r\n" {
+		t.Errorf("crlfWriter output = %q, want %q", buf.String(), "a\r\nb\r\nc\r\n")
+	}
+}
+
+func TestLineEndingCRLF(t *testing.T) {
+	path := writeGraphFile(t, testGraph)
+
+	output, err := executeRoot(t, "--line-ending", "crlf", path)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	lines := strings.SplitAfter(output, "\n")
+	for _, line := range lines[:len(lines)-1] {
+		if !strings.HasSuffix(line, "\r\n") {
+			t.Errorf("line %q should end with CRLF", line)
+		}
+	}
+
+	if _, err := executeRoot(t, "--line-ending", "cr", path); err == nil {
+		t.Error("Execute() should reject unsupported line endings")
+	}
+}
+
+func TestTee(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	teePath := filepath.Join(t.TempDir(), "tee.txt")
+
+	output, err := executeRoot(t, "--tee", teePath, "--line-ending", "crlf", graphPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	teed, err := os.ReadFile(teePath)
+	if err != nil {
+		t.Fatalf("failed to read tee file: %v", err)
+	}
+	if string(teed) != output || output == "" {
+		t.Errorf("tee file = %q, want the stdout output %q", teed, output)
+	}
+}
+
+func TestTeeKeepsFileOnFailure(t *testing.T) {
+	graphPath := writeGraphFile(t, testGraph)
+	teePath := filepath.Join(t.TempDir(), "tee.txt")
+	if err := os.WriteFile(teePath, []byte("previous"), 0o600); err != nil {
+		t.Fatalf("failed to write tee file: %v", err)
+	}
+
+	if _, err := executeRoot(t, "--tee", teePath, "--reverse-tree", "github.com/missing", graphPath); err == nil {
+		t.Fatal("Execute() should fail for a missing module")
+	}
+	if teed, err := os.ReadFile(teePath); err != nil || string(teed) != "previous" {
+		t.Errorf("tee file = %q, %v, want the previous contents kept", teed, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(teePath)); len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
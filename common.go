@@ -0,0 +1,74 @@
+package tangled
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DefaultCommonModules lists widely shared infrastructure modules that add
+// little to an application-level view of a graph. Each entry is a path
+// prefix as accepted by Exclude.
+var DefaultCommonModules = []string{
+	"golang.org/x/crypto",
+	"golang.org/x/exp",
+	"golang.org/x/mod",
+	"golang.org/x/net",
+	"golang.org/x/sync",
+	"golang.org/x/sys",
+	"golang.org/x/term",
+	"golang.org/x/text",
+	"golang.org/x/time",
+	"golang.org/x/tools",
+	"golang.org/x/xerrors",
+	"google.golang.org/protobuf",
+	"github.com/davecgh/go-spew",
+	"github.com/pmezard/go-difflib",
+	"gopkg.in/check.v1",
+	"gopkg.in/yaml.v3",
+}
+
+// ParseModulePathsFromFile reads a file of module path prefixes
+func ParseModulePathsFromFile(filename string) ([]string, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseModulePaths(file)
+}
+
+// ParseModulePaths reads one module path prefix per line, in file order.
+// Blank lines and lines starting with # are ignored.
+func ParseModulePaths(reader io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(reader)
+	var paths []string
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if fields := strings.Fields(line); len(fields) != 1 {
+			return nil, ParseError{
+				Line:    lineNum,
+				Content: line,
+				Err:     fmt.Errorf("expected 1 field, got %d", len(fields)),
+			}
+		}
+		paths = append(paths, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+
+	return paths, nil
+}
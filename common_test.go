@@ -0,0 +1,40 @@
+package tangled
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseModulePaths(t *testing.T) {
+	input := "# infrastructure\ngolang.org/x/sys\n\ngithub.com/internal/\n"
+
+	paths, err := ParseModulePaths(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseModulePaths() error = %v", err)
+	}
+	if len(paths) != 2 || paths[0] != "golang.org/x/sys" || paths[1] != "github.com/internal/" {
+		t.Errorf("ParseModulePaths() = %v", paths)
+	}
+
+	if _, err := ParseModulePaths(strings.NewReader("golang.org/x/sys v0.1.0\n")); err == nil {
+		t.Error("ParseModulePaths() should reject lines with more than one field")
+	}
+}
+
+func TestDependencyGraph_ExcludeCommon(t *testing.T) {
+	graph := createTestGraph()
+	sys := Module{Path: "golang.org/x/sys", Version: "v0.20.0"}
+	text := Module{Path: "golang.org/x/text", Version: "v0.15.0"}
+	graph.AddDependency(graph.MainModule, sys)
+	graph.AddDependency(Module{Path: "github.com/dep1", Version: "v1.0.0"}, text)
+
+	filtered := graph.Exclude(DefaultCommonModules)
+	for _, module := range filtered.GetAllModules() {
+		if module.Path == sys.Path || module.Path == text.Path {
+			t.Errorf("common module %s should be hidden", module)
+		}
+	}
+	if got := len(filtered.Dependencies); got != 3 {
+		t.Errorf("kept %d edges, want the 3 application edges", got)
+	}
+}
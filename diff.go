@@ -0,0 +1,90 @@
+package tangled
+
+import "fmt"
+
+// GraphDiff describes the modules and edges that differ between two graphs
+type GraphDiff struct {
+	AddedModules   []Module
+	RemovedModules []Module
+	AddedEdges     []Dependency
+	RemovedEdges   []Dependency
+	DepthChanges   []DepthChange
+}
+
+// DepthChange records a module whose distance from the main module differs
+// between two graphs
+type DepthChange struct {
+	Module Module
+	Before int
+	After  int
+}
+
+// String returns a one-line description of the change
+func (dc DepthChange) String() string {
+	return fmt.Sprintf("%s: depth %d -> %d", dc.Module.String(), dc.Before, dc.After)
+}
+
+// Diff compares two graphs by module string. Modules and depth changes are
+// returned in sorted module order and edges in the order they appear in their
+// graph. Depth changes cover modules reachable from the main module in both.
+func Diff(before, after *DependencyGraph) GraphDiff {
+	var diff GraphDiff
+
+	beforeModules := moduleSet(before)
+	afterModules := moduleSet(after)
+	for _, module := range after.GetAllModules() {
+		if !beforeModules[module.String()] {
+			diff.AddedModules = append(diff.AddedModules, module)
+		}
+	}
+	for _, module := range before.GetAllModules() {
+		if !afterModules[module.String()] {
+			diff.RemovedModules = append(diff.RemovedModules, module)
+		}
+	}
+
+	beforeEdges := before.EdgeCounts()
+	afterEdges := after.EdgeCounts()
+	for _, dep := range after.Dependencies {
+		if beforeEdges[edgeKey(dep)] == 0 {
+			diff.AddedEdges = append(diff.AddedEdges, dep)
+		}
+	}
+	for _, dep := range before.Dependencies {
+		if afterEdges[edgeKey(dep)] == 0 {
+			diff.RemovedEdges = append(diff.RemovedEdges, dep)
+		}
+	}
+
+	beforeDepths := before.DepthMap()
+	afterDepths := after.DepthMap()
+	for _, module := range after.GetAllModules() {
+		moduleStr := module.String()
+		b, inBefore := beforeDepths[moduleStr]
+		a, inAfter := afterDepths[moduleStr]
+		if inBefore && inAfter && a != b {
+			diff.DepthChanges = append(diff.DepthChanges, DepthChange{Module: module, Before: b, After: a})
+		}
+	}
+
+	return diff
+}
+
+// moduleSet returns the string form of every module in the graph
+func moduleSet(dg *DependencyGraph) map[string]bool {
+	set := make(map[string]bool)
+	for _, module := range dg.GetAllModules() {
+		set[module.String()] = true
+	}
+	return set
+}
+
+// AddedSince returns a copy of the graph keeping only the edges that lead to
+// modules absent from before, so every newly added module appears alongside
+// the modules that require it
+func (dg *DependencyGraph) AddedSince(before *DependencyGraph) *DependencyGraph {
+	existing := moduleSet(before)
+	return dg.subgraph(func(dep Dependency) bool {
+		return !existing[dep.To.String()]
+	})
+}
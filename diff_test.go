@@ -0,0 +1,80 @@
+package tangled
+
+import (
+	"strings"
+	"testing"
+)
+
+const diffOldGraph = `github.com/example/main github.com/dep1@v1.0.0
+github.com/example/main github.com/dep2@v2.0.0
+github.com/dep1@v1.0.0 github.com/subdep@v1.0.0
+`
+
+const diffNewGraph = `github.com/example/main github.com/dep1@v1.0.0
+github.com/example/main github.com/dep3@v1.0.0
+github.com/dep1@v1.0.0 github.com/subdep@v1.0.0
+github.com/dep3@v1.0.0 github.com/dep3sub@v0.1.0
+`
+
+func parseDiffGraphs(t *testing.T) (*DependencyGraph, *DependencyGraph) {
+	t.Helper()
+
+	before, err := ParseGraph(strings.NewReader(diffOldGraph))
+	if err != nil {
+		t.Fatalf("ParseGraph(before) error = %v", err)
+	}
+	after, err := ParseGraph(strings.NewReader(diffNewGraph))
+	if err != nil {
+		t.Fatalf("ParseGraph(after) error = %v", err)
+	}
+	return before, after
+}
+
+func TestDiff(t *testing.T) {
+	before, after := parseDiffGraphs(t)
+
+	diff := Diff(before, after)
+	if len(diff.AddedModules) != 2 || diff.AddedModules[0].String() != "github.com/dep3@v1.0.0" || diff.AddedModules[1].String() != "github.com/dep3sub@v0.1.0" {
+		t.Errorf("AddedModules = %v, want dep3 and dep3sub", diff.AddedModules)
+	}
+	if len(diff.RemovedModules) != 1 || diff.RemovedModules[0].String() != "github.com/dep2@v2.0.0" {
+		t.Errorf("RemovedModules = %v, want dep2", diff.RemovedModules)
+	}
+	if len(diff.AddedEdges) != 2 {
+		t.Errorf("AddedEdges = %v, want 2", diff.AddedEdges)
+	}
+	if len(diff.RemovedEdges) != 1 {
+		t.Errorf("RemovedEdges = %v, want 1", diff.RemovedEdges)
+	}
+}
+
+func TestDiff_DepthChanges(t *testing.T) {
+	before, after := parseDiffGraphs(t)
+	after.AddDependency(after.MainModule, Module{Path: "github.com/subdep", Version: "v1.0.0"})
+
+	changes := Diff(before, after).DepthChanges
+	if len(changes) != 1 {
+		t.Fatalf("DepthChanges = %v, want one change", changes)
+	}
+	if change := changes[0]; change.Module.Path != "github.com/subdep" || change.Before != 2 || change.After != 1 {
+		t.Errorf("DepthChanges[0] = %+v, want subdep moving from depth 2 to 1", change)
+	}
+	if got, want := changes[0].String(), "github.com/subdep@v1.0.0: depth 2 -> 1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestDependencyGraph_AddedSince(t *testing.T) {
+	before, after := parseDiffGraphs(t)
+
+	added := after.AddedSince(before)
+	for _, dep := range added.Dependencies {
+		if dep.To.Path != "github.com/dep3" && dep.To.Path != "github.com/dep3sub" {
+			t.Errorf("edge to existing module %v should be dropped", dep.To)
+		}
+	}
+	modules := added.GetAllModules()
+	if len(modules) != 3 {
+		t.Errorf("modules = %v, want main, dep3 and dep3sub", modules)
+	}
+}
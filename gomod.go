@@ -0,0 +1,202 @@
+package tangled
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ParseGoModRequiresFromFile reads a go.mod file and returns its required modules
+func ParseGoModRequiresFromFile(filename string) ([]Module, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseGoModRequires(file)
+}
+
+// ParseGoModRequires returns the modules listed in the require directives of
+// a go.mod file, in file order. Other directives are ignored.
+func ParseGoModRequires(reader io.Reader) ([]Module, error) {
+	var requires []Module
+	err := scanGoModDirective(reader, "require", func(fields []string) error {
+		if len(fields) != 2 {
+			return fmt.Errorf("expected module path and version, got %d fields", len(fields))
+		}
+		requires = append(requires, Module{Path: strings.Trim(fields[0], `"`), Version: fields[1]})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return requires, nil
+}
+
+// Replace is a replace directive from a go.mod file. An empty Old.Version
+// replaces every version of the path; New.Version is empty for local paths.
+type Replace struct {
+	Old Module
+	New Module
+}
+
+// Matches reports whether the directive applies to module
+func (r Replace) Matches(module Module) bool {
+	return module.Path == r.Old.Path && (r.Old.Version == "" || module.Version == r.Old.Version)
+}
+
+// ParseGoModReplacesFromFile reads a go.mod file and returns its replace directives
+func ParseGoModReplacesFromFile(filename string) ([]Replace, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseGoModReplaces(file)
+}
+
+// ParseGoModReplaces returns the replace directives of a go.mod file, in
+// file order. Other directives are ignored.
+func ParseGoModReplaces(reader io.Reader) ([]Replace, error) {
+	var replaces []Replace
+	err := scanGoModDirective(reader, "replace", func(fields []string) error {
+		arrow := -1
+		for i, field := range fields {
+			if field == "=>" {
+				arrow = i
+				break
+			}
+		}
+		if arrow < 1 || arrow > 2 || len(fields)-arrow-1 < 1 || len(fields)-arrow-1 > 2 {
+			return fmt.Errorf("expected \"old [version] => new [version]\"")
+		}
+
+		replace := Replace{
+			Old: Module{Path: strings.Trim(fields[0], `"`)},
+			New: Module{Path: strings.Trim(fields[arrow+1], `"`)},
+		}
+		if arrow == 2 {
+			replace.Old.Version = fields[1]
+		}
+		if len(fields) == arrow+3 {
+			replace.New.Version = fields[arrow+2]
+		}
+		replaces = append(replaces, replace)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return replaces, nil
+}
+
+// scanGoModDirective calls fn with the fields of every entry of the named
+// directive, whether written on one line or in a parenthesized block.
+// Comments are stripped and errors from fn are reported with their line.
+func scanGoModDirective(reader io.Reader, directive string, fn func(fields []string) error) error {
+	scanner := bufio.NewScanner(reader)
+	lineNum := 0
+	inBlock := false
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		// Strip comments, including the "// indirect" marker
+		content := line
+		if idx := strings.Index(content, "//"); idx != -1 {
+			content = content[:idx]
+		}
+		fields := strings.Fields(content)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if inBlock {
+			if fields[0] == ")" {
+				inBlock = false
+				continue
+			}
+		} else {
+			if fields[0] != directive {
+				continue
+			}
+			if len(fields) == 2 && fields[1] == "(" {
+				inBlock = true
+				continue
+			}
+			fields = fields[1:]
+		}
+
+		if err := fn(fields); err != nil {
+			return ParseError{Line: lineNum, Content: line, Err: err}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading input: %w", err)
+	}
+	if inBlock {
+		return fmt.Errorf("unterminated %s block", directive)
+	}
+
+	return nil
+}
+
+// UnusedRequires returns the requires that do not appear as direct edges
+// from the main module, in their original order
+func (dg *DependencyGraph) UnusedRequires(requires []Module) []Module {
+	direct := make(map[string]bool)
+	for _, module := range dg.GetDirectDependencies(dg.MainModule) {
+		direct[module.String()] = true
+	}
+
+	var unused []Module
+	for _, module := range requires {
+		if !direct[module.String()] {
+			unused = append(unused, module)
+		}
+	}
+	return unused
+}
+
+// ReplaceImpact returns the modules reachable from the main module only
+// through modules matched by a replace directive, sorted by module string.
+// These are the requirements that would drop out of the graph if the
+// replaces were removed. The replaced modules themselves are not included.
+func (dg *DependencyGraph) ReplaceImpact(replaces []Replace) []Module {
+	tree := dg.GetTree()
+	pruned := make(map[string][]string, len(tree))
+	for from, children := range tree {
+		pruned[from] = children
+	}
+	for _, module := range dg.GetAllModules() {
+		for _, replace := range replaces {
+			if replace.Matches(module) {
+				delete(pruned, module.String())
+				break
+			}
+		}
+	}
+
+	root := dg.MainModule.String()
+	all := reachableIn(tree, root, nil)
+	kept := reachableIn(pruned, root, nil)
+
+	var impacted []Module
+	for _, module := range dg.GetAllModules() {
+		moduleStr := module.String()
+		if all[moduleStr] && !kept[moduleStr] {
+			impacted = append(impacted, module)
+		}
+	}
+	sort.Slice(impacted, func(i, j int) bool {
+		return impacted[i].String() < impacted[j].String()
+	})
+	return impacted
+}
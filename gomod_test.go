@@ -0,0 +1,116 @@
+package tangled
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGoModRequires(t *testing.T) {
+	input := `module github.com/example/main
+
+go 1.24
+
+require github.com/dep1 v1.0.0
+
+require (
+	github.com/dep2 v2.0.0 // indirect
+	// a comment
+	github.com/stale v0.3.0
+)
+
+replace github.com/dep1 => ../dep1
+`
+
+	requires, err := ParseGoModRequires(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGoModRequires() error = %v", err)
+	}
+
+	want := []Module{
+		{Path: "github.com/dep1", Version: "v1.0.0"},
+		{Path: "github.com/dep2", Version: "v2.0.0"},
+		{Path: "github.com/stale", Version: "v0.3.0"},
+	}
+	if len(requires) != len(want) {
+		t.Fatalf("requires = %v, want %v", requires, want)
+	}
+	for i := range want {
+		if requires[i] != want[i] {
+			t.Errorf("requires[%d] = %v, want %v", i, requires[i], want[i])
+		}
+	}
+}
+
+func TestParseGoModRequiresErrors(t *testing.T) {
+	for _, input := range []string{"require github.com/dep1", "require (\n\tgithub.com/dep1 v1.0.0\n"} {
+		if _, err := ParseGoModRequires(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseGoModRequires(%q) should fail", input)
+		}
+	}
+}
+
+func TestDependencyGraph_UnusedRequires(t *testing.T) {
+	graph := createTestGraph()
+	requires := []Module{
+		{Path: "github.com/dep1", Version: "v1.0.0"},
+		{Path: "github.com/stale", Version: "v0.3.0"},
+	}
+
+	unused := graph.UnusedRequires(requires)
+	if len(unused) != 1 || unused[0] != requires[1] {
+		t.Errorf("UnusedRequires() = %v, want [%v]", unused, requires[1])
+	}
+}
+
+func TestParseGoModReplaces(t *testing.T) {
+	input := `module github.com/example/main
+
+require github.com/dep1 v1.0.0
+
+replace github.com/dep1 => ../dep1
+
+replace (
+	github.com/dep2 v2.0.0 => github.com/fork/dep2 v2.0.1 // pinned fork
+)
+`
+
+	replaces, err := ParseGoModReplaces(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGoModReplaces() error = %v", err)
+	}
+
+	want := []Replace{
+		{Old: Module{Path: "github.com/dep1"}, New: Module{Path: "../dep1"}},
+		{Old: Module{Path: "github.com/dep2", Version: "v2.0.0"}, New: Module{Path: "github.com/fork/dep2", Version: "v2.0.1"}},
+	}
+	if len(replaces) != len(want) {
+		t.Fatalf("replaces = %v, want %v", replaces, want)
+	}
+	for i := range want {
+		if replaces[i] != want[i] {
+			t.Errorf("replaces[%d] = %v, want %v", i, replaces[i], want[i])
+		}
+	}
+
+	for _, input := range []string{"replace github.com/dep1", "replace github.com/dep1 =>", "replace a b c => d"} {
+		if _, err := ParseGoModReplaces(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseGoModReplaces(%q) should fail", input)
+		}
+	}
+}
+
+func TestDependencyGraph_ReplaceImpact(t *testing.T) {
+	graph := createTestGraph()
+	shared := Module{Path: "github.com/shared", Version: "v1.0.0"}
+	graph.AddDependency(Module{Path: "github.com/dep1", Version: "v1.0.0"}, shared)
+	graph.AddDependency(Module{Path: "github.com/dep2", Version: "v2.0.0"}, shared)
+
+	impacted := graph.ReplaceImpact([]Replace{{Old: Module{Path: "github.com/dep1"}, New: Module{Path: "../dep1"}}})
+	if len(impacted) != 1 || impacted[0].Path != "github.com/subdep" {
+		t.Errorf("ReplaceImpact() = %v, want only subdep orphaned", impacted)
+	}
+
+	if impacted := graph.ReplaceImpact([]Replace{{Old: Module{Path: "github.com/dep1", Version: "v0.9.0"}}}); len(impacted) != 0 {
+		t.Errorf("ReplaceImpact() = %v, want none for a non-matching version", impacted)
+	}
+}
@@ -0,0 +1,102 @@
+package tangled
+
+import (
+	"fmt"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/flow"
+	"gonum.org/v1/gonum/graph/network"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// AsGonum builds a gonum directed graph from the dependency graph so callers
+// can run gonum's graph algorithms. Node IDs follow the sorted order of
+// GetAllModules and the returned map resolves them back to modules.
+// Duplicate edges are merged and self-edges are skipped, as gonum's simple
+// graphs do not support them.
+func (dg *DependencyGraph) AsGonum() (graph.Directed, map[int64]Module) {
+	g := simple.NewDirectedGraph()
+	idToModule := make(map[int64]Module)
+	moduleToID := make(map[string]int64)
+
+	for i, module := range dg.GetAllModules() {
+		id := int64(i)
+		g.AddNode(simple.Node(id))
+		idToModule[id] = module
+		moduleToID[module.String()] = id
+	}
+
+	for _, dep := range dg.Dependencies {
+		from := moduleToID[dep.From.String()]
+		to := moduleToID[dep.To.String()]
+		if from == to {
+			continue
+		}
+		g.SetEdge(g.NewEdge(simple.Node(from), simple.Node(to)))
+	}
+
+	return g, idToModule
+}
+
+// CentralityMode selects which edges make a module central: its incoming
+// edges (modules that depend on it), its outgoing edges (modules it depends
+// on), or both
+type CentralityMode string
+
+// Centrality modes accepted by TopCentralBy and DegreeCentrality
+const (
+	CentralityIn   CentralityMode = "in"
+	CentralityOut  CentralityMode = "out"
+	CentralityBoth CentralityMode = "both"
+)
+
+// ParseCentralityMode parses "in", "out" or "both" into a CentralityMode
+func ParseCentralityMode(s string) (CentralityMode, error) {
+	switch mode := CentralityMode(strings.ToLower(s)); mode {
+	case CentralityIn, CentralityOut, CentralityBoth:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported centrality: %s (supported: in, out, both)", s)
+	}
+}
+
+// Centrality returns the PageRank of every module, keyed by module string.
+// Modules that many others depend on, directly or through other central
+// modules, score highest.
+func (dg *DependencyGraph) Centrality() map[string]float64 {
+	g, modules := dg.AsGonum()
+	ranks := network.PageRankSparse(g, 0.85, 1e-8)
+
+	centrality := make(map[string]float64, len(ranks))
+	for id, rank := range ranks {
+		centrality[modules[id].String()] = rank
+	}
+	return centrality
+}
+
+// Dominators returns the immediate dominator of every module reachable from
+// the main module, keyed by module string. A module's immediate dominator is
+// the closest module that every path from the main module to it passes
+// through, so removing the dominator cuts it off. The main module and
+// unreachable modules have no entry.
+func (dg *DependencyGraph) Dominators() map[string]string {
+	g, modules := dg.AsGonum()
+	main := dg.MainModule.String()
+	var root graph.Node
+	for id, module := range modules {
+		if module.String() == main {
+			root = g.Node(id)
+			break
+		}
+	}
+
+	tree := flow.Dominators(root, g)
+	dominators := make(map[string]string)
+	for id, module := range modules {
+		if dominator := tree.DominatorOf(id); dominator != nil {
+			dominators[module.String()] = modules[dominator.ID()].String()
+		}
+	}
+	return dominators
+}
@@ -0,0 +1,97 @@
+package tangled
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestDependencyGraph_AsGonum(t *testing.T) {
+	graph := createTestGraph()
+
+	g, modules := graph.AsGonum()
+
+	if got := g.Nodes().Len(); got != len(graph.GetAllModules()) {
+		t.Errorf("gonum graph has %d nodes, want %d", got, len(graph.GetAllModules()))
+	}
+
+	edges := 0
+	nodes := g.Nodes()
+	for nodes.Next() {
+		edges += g.From(nodes.Node().ID()).Len()
+	}
+	if edges != len(graph.Dependencies) {
+		t.Errorf("gonum graph has %d edges, want %d", edges, len(graph.Dependencies))
+	}
+
+	for _, dep := range graph.Dependencies {
+		var from, to int64 = -1, -1
+		for id, module := range modules {
+			if module == dep.From {
+				from = id
+			}
+			if module == dep.To {
+				to = id
+			}
+		}
+		if !g.HasEdgeFromTo(from, to) {
+			t.Errorf("gonum graph is missing edge %v -> %v", dep.From, dep.To)
+		}
+	}
+
+	if _, err := topo.Sort(g); err != nil {
+		t.Errorf("acyclic dependency graph should sort topologically: %v", err)
+	}
+}
+
+func TestDependencyGraph_Centrality(t *testing.T) {
+	graph := createTestGraph()
+
+	centrality := graph.Centrality()
+	if len(centrality) != len(graph.GetAllModules()) {
+		t.Fatalf("centrality has %d modules, want %d", len(centrality), len(graph.GetAllModules()))
+	}
+	if centrality["github.com/subdep@v1.0.0"] <= centrality["github.com/example/main"] {
+		t.Errorf("a depended-on module should be more central than the root, got %v", centrality)
+	}
+}
+
+func TestParseCentralityMode(t *testing.T) {
+	for _, s := range []string{"in", "OUT", "both"} {
+		if _, err := ParseCentralityMode(s); err != nil {
+			t.Errorf("ParseCentralityMode(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseCentralityMode("sideways"); err == nil {
+		t.Error("ParseCentralityMode(\"sideways\") should fail")
+	}
+}
+
+func TestDependencyGraph_Dominators(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+	d := Module{Path: "example.com/d", Version: "v1.0.0"}
+	e := Module{Path: "example.com/e", Version: "v1.0.0"}
+
+	// a -> b -> c, plus a diamond a -> d, b -> e and d -> e, and an
+	// unreachable module pointing at c
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, c)
+	graph.AddDependency(a, d)
+	graph.AddDependency(b, e)
+	graph.AddDependency(d, e)
+	graph.AddDependency(Module{Path: "example.com/orphan"}, c)
+
+	want := map[string]string{
+		b.String(): a.String(),
+		c.String(): b.String(),
+		d.String(): a.String(),
+		e.String(): a.String(),
+	}
+	if got := graph.Dominators(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Dominators() = %v, want %v", got, want)
+	}
+}
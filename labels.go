@@ -0,0 +1,107 @@
+package tangled
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultLabelTemplate reproduces the standard path@version module label
+const DefaultLabelTemplate = `{{.Path}}{{if .Version}}@{{.Version}}{{end}}`
+
+// NodeLabel holds the fields available to a label template
+type NodeLabel struct {
+	Path    string
+	Version string
+	Depth   int // distance from the main module, -1 when unreachable
+}
+
+// LabelTemplate formats node labels using a text/template
+type LabelTemplate struct {
+	tmpl *template.Template
+}
+
+// NewLabelTemplate parses a label template and validates it against a sample node
+func NewLabelTemplate(text string) (*LabelTemplate, error) {
+	tmpl, err := template.New("label").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label template: %w", err)
+	}
+
+	lt := &LabelTemplate{tmpl: tmpl}
+
+	// Execute once so references to unknown fields fail at startup rather than mid-render
+	if _, err := lt.Label(Module{Path: "example.com/module", Version: "v1.0.0"}, 0); err != nil {
+		return nil, err
+	}
+
+	return lt, nil
+}
+
+// Label renders the label for a module at the given depth
+func (lt *LabelTemplate) Label(module Module, depth int) (string, error) {
+	var sb strings.Builder
+	err := lt.tmpl.Execute(&sb, NodeLabel{Path: module.Path, Version: module.Version, Depth: depth})
+	if err != nil {
+		return "", fmt.Errorf("invalid label template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// aliasModule returns the module with its path replaced by its alias, if any
+func aliasModule(module Module, aliases map[string]string) Module {
+	if alias, ok := aliases[module.Path]; ok {
+		module.Path = alias
+	}
+	return module
+}
+
+// nodeLabels returns the display label for every module in the graph, keyed
+// by module string, substituting aliased paths before formatting
+func nodeLabels(graph *DependencyGraph, lt *LabelTemplate, aliases map[string]string) (map[string]string, error) {
+	modules := graph.GetAllModules()
+	labels := make(map[string]string, len(modules))
+
+	if lt == nil {
+		for _, module := range modules {
+			labels[module.String()] = aliasModule(module, aliases).String()
+		}
+		return labels, nil
+	}
+
+	depths := graph.DepthMap()
+	for _, module := range modules {
+		moduleStr := module.String()
+		depth, ok := depths[moduleStr]
+		if !ok {
+			depth = -1
+		}
+
+		label, err := lt.Label(aliasModule(module, aliases), depth)
+		if err != nil {
+			return nil, err
+		}
+		labels[moduleStr] = label
+	}
+
+	return labels, nil
+}
+
+// disambiguateLabels returns a copy of labels in which every label is unique.
+// Modules are visited in the given order; the first to use a label keeps it
+// and later ones get a " (2)", " (3)", ... suffix.
+func disambiguateLabels(modules []Module, labels map[string]string) map[string]string {
+	unique := make(map[string]string, len(labels))
+	used := make(map[string]bool, len(labels))
+	for _, module := range modules {
+		moduleStr := module.String()
+		label := labels[moduleStr]
+		candidate := label
+		for n := 2; used[candidate]; n++ {
+			candidate = fmt.Sprintf("%s (%d)", label, n)
+		}
+		used[candidate] = true
+		unique[moduleStr] = candidate
+	}
+	return unique
+}
@@ -0,0 +1,75 @@
+package tangled
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewLabelTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "default", text: DefaultLabelTemplate},
+		{name: "path only", text: "{{.Path}}"},
+		{name: "with depth", text: "{{.Depth}}: {{.Path}}"},
+		{name: "syntax error", text: "{{.Path", wantErr: true},
+		{name: "unknown field", text: "{{.Name}}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLabelTemplate(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLabelTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLabelTemplate_Label(t *testing.T) {
+	lt, err := NewLabelTemplate(DefaultLabelTemplate)
+	if err != nil {
+		t.Fatalf("NewLabelTemplate() error = %v", err)
+	}
+
+	module := Module{Path: "github.com/dep1", Version: "v1.0.0"}
+	got, err := lt.Label(module, 1)
+	if err != nil {
+		t.Fatalf("Label() error = %v", err)
+	}
+	if got != module.String() {
+		t.Errorf("Label() = %q, want %q", got, module.String())
+	}
+}
+
+func TestLabelTemplate_PathOnly(t *testing.T) {
+	lt, err := NewLabelTemplate("{{.Path}}")
+	if err != nil {
+		t.Fatalf("NewLabelTemplate() error = %v", err)
+	}
+
+	graph := createTestGraph()
+	renderers := []Renderer{
+		&PlaintextRenderer{RenderOptions: RenderOptions{Labels: lt}},
+		&MermaidRenderer{RenderOptions: RenderOptions{Labels: lt}},
+		&GraphvizRenderer{RenderOptions: RenderOptions{Labels: lt}},
+	}
+
+	for _, renderer := range renderers {
+		var buf bytes.Buffer
+		if err := renderer.Render(graph, &buf); err != nil {
+			t.Fatalf("%T.Render() error = %v", renderer, err)
+		}
+
+		output := buf.String()
+		if !strings.Contains(output, "github.com/dep1") {
+			t.Errorf("%T output should contain module path", renderer)
+		}
+		if strings.Contains(output, "@v1.0.0") {
+			t.Errorf("%T output should omit versions, got:\n%s", renderer, output)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package tangled
+
+// Point is a position in a two-dimensional layout
+type Point struct {
+	X, Y float64
+}
+
+// LayeredLayout places modules in columns by their depth from the main
+// module within a width×height canvas, spacing each column evenly from top to
+// bottom in sorted order. Unreachable modules share a final column.
+func (dg *DependencyGraph) LayeredLayout(width, height float64) map[string]Point {
+	depths := dg.DepthMap()
+	maxDepth := 0
+	for _, depth := range depths {
+		maxDepth = max(maxDepth, depth)
+	}
+
+	layers := make([][]string, maxDepth+2)
+	for _, module := range dg.GetAllModules() {
+		moduleStr := module.String()
+		depth, ok := depths[moduleStr]
+		if !ok {
+			depth = maxDepth + 1
+		}
+		layers[depth] = append(layers[depth], moduleStr)
+	}
+	if len(layers[len(layers)-1]) == 0 {
+		layers = layers[:len(layers)-1]
+	}
+
+	// Divide each axis into equal bands and place modules at band centers,
+	// keeping every coordinate strictly inside the canvas
+	positions := make(map[string]Point)
+	columnWidth := width / float64(len(layers))
+	for depth, layer := range layers {
+		rowHeight := height / float64(len(layer))
+		for i, moduleStr := range layer {
+			positions[moduleStr] = Point{
+				X: columnWidth * (float64(depth) + 0.5),
+				Y: rowHeight * (float64(i) + 0.5),
+			}
+		}
+	}
+	return positions
+}
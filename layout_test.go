@@ -0,0 +1,26 @@
+package tangled
+
+import "testing"
+
+func TestDependencyGraph_LayeredLayout(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(Module{Path: "github.com/orphan", Version: "v1.0.0"}, Module{Path: "github.com/other", Version: "v1.0.0"})
+
+	positions := graph.LayeredLayout(1200, 800)
+	if len(positions) != len(graph.GetAllModules()) {
+		t.Fatalf("positions = %d, want one per module", len(positions))
+	}
+	for module, p := range positions {
+		if p.X <= 0 || p.X >= 1200 || p.Y <= 0 || p.Y >= 800 {
+			t.Errorf("%s at %v, want inside the canvas", module, p)
+		}
+	}
+
+	main := positions["github.com/example/main"]
+	dep1 := positions["github.com/dep1@v1.0.0"]
+	subdep := positions["github.com/subdep@v1.0.0"]
+	orphan := positions["github.com/orphan@v1.0.0"]
+	if !(main.X < dep1.X && dep1.X < subdep.X && subdep.X < orphan.X) {
+		t.Errorf("columns should follow depth with unreachable modules last, got main=%v dep1=%v subdep=%v orphan=%v", main, dep1, subdep, orphan)
+	}
+}
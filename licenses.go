@@ -0,0 +1,62 @@
+package tangled
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LicenseViolation records a module carrying a denied license
+type LicenseViolation struct {
+	Module  Module
+	License string
+}
+
+// ParseLicensesFromFile reads a license map file and returns licenses keyed by module path
+func ParseLicensesFromFile(filename string) (map[string]string, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseLicenses(file)
+}
+
+// ParseLicenses reads "module-path SPDX-license" lines and returns licenses
+// keyed by module path. Blank lines and lines starting with # are ignored.
+func ParseLicenses(reader io.Reader) (map[string]string, error) {
+	return parsePairs(reader)
+}
+
+// ApplyLicenses sets the License of every module in the graph from a map keyed by module path
+func (dg *DependencyGraph) ApplyLicenses(licenses map[string]string) {
+	attach := func(module Module) Module {
+		module.License = licenses[module.Path]
+		return module
+	}
+
+	dg.MainModule = attach(dg.MainModule)
+	for i, dep := range dg.Dependencies {
+		dg.Dependencies[i].From = attach(dep.From)
+		dg.Dependencies[i].To = attach(dep.To)
+	}
+}
+
+// CheckLicenses returns every module whose license is in the denied list,
+// compared case-insensitively, in sorted module order
+func (dg *DependencyGraph) CheckLicenses(denied []string) []LicenseViolation {
+	deniedSet := make(map[string]bool)
+	for _, license := range denied {
+		deniedSet[strings.ToLower(license)] = true
+	}
+
+	var violations []LicenseViolation
+	for _, module := range dg.GetAllModules() {
+		if module.License != "" && deniedSet[strings.ToLower(module.License)] {
+			violations = append(violations, LicenseViolation{Module: module, License: module.License})
+		}
+	}
+	return violations
+}
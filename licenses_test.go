@@ -0,0 +1,47 @@
+package tangled
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLicenses(t *testing.T) {
+	input := `# module licenses
+github.com/dep1 MIT
+
+github.com/dep2 GPL-3.0`
+
+	licenses, err := ParseLicenses(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseLicenses() error = %v", err)
+	}
+
+	if licenses["github.com/dep1"] != "MIT" || licenses["github.com/dep2"] != "GPL-3.0" {
+		t.Errorf("ParseLicenses() = %v", licenses)
+	}
+
+	_, err = ParseLicenses(strings.NewReader("github.com/dep1"))
+	if err == nil {
+		t.Error("ParseLicenses() should reject lines without a license")
+	}
+}
+
+func TestDependencyGraph_CheckLicenses(t *testing.T) {
+	graph := createTestGraph()
+	graph.ApplyLicenses(map[string]string{
+		"github.com/dep1":   "MIT",
+		"github.com/subdep": "GPL-3.0",
+	})
+
+	violations := graph.CheckLicenses([]string{"gpl-3.0"})
+	if len(violations) != 1 {
+		t.Fatalf("CheckLicenses() returned %d violations, want 1", len(violations))
+	}
+	if violations[0].Module.Path != "github.com/subdep" || violations[0].License != "GPL-3.0" {
+		t.Errorf("CheckLicenses()[0] = %+v", violations[0])
+	}
+
+	if violations := graph.CheckLicenses([]string{"AGPL-3.0"}); len(violations) != 0 {
+		t.Errorf("CheckLicenses() = %v, want none", violations)
+	}
+}
@@ -0,0 +1,50 @@
+package tangled
+
+import "sync"
+
+// parallelThreshold is the module count below which parallel computations
+// run serially, since goroutine overhead outweighs the gain
+const parallelThreshold = 512
+
+// moduleKeys returns the string form of each module, preserving order
+func moduleKeys(modules []Module) []string {
+	keys := make([]string, len(modules))
+	for i, module := range modules {
+		keys[i] = module.String()
+	}
+	return keys
+}
+
+// mapModules applies fn to every key and collects the results, fanning out
+// over a pool of workers for large inputs; fn must be safe for concurrent use
+func mapModules[V any](keys []string, workers int, fn func(string) V) map[string]V {
+	results := make([]V, len(keys))
+	if workers <= 1 || len(keys) < parallelThreshold {
+		for i, key := range keys {
+			results[i] = fn(key)
+		}
+	} else {
+		indexes := make(chan int)
+		var wg sync.WaitGroup
+		for range min(workers, len(keys)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexes {
+					results[i] = fn(keys[i])
+				}
+			}()
+		}
+		for i := range keys {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+	}
+
+	byKey := make(map[string]V, len(keys))
+	for i, key := range keys {
+		byKey[key] = results[i]
+	}
+	return byKey
+}
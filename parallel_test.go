@@ -0,0 +1,70 @@
+package tangled
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// syntheticGraph builds a layered graph where each module depends on a few
+// modules in the next layer
+func syntheticGraph(layers, width int) *DependencyGraph {
+	module := func(layer, i int) Module {
+		return Module{Path: fmt.Sprintf("example.com/l%d/m%d", layer, i), Version: "v1.0.0"}
+	}
+
+	main := Module{Path: "example.com/main"}
+	graph := NewDependencyGraph(main)
+	for i := 0; i < width; i++ {
+		graph.AddDependency(main, module(0, i))
+	}
+	for layer := 0; layer < layers-1; layer++ {
+		for i := 0; i < width; i++ {
+			for k := 0; k < 3; k++ {
+				graph.AddDependency(module(layer, i), module(layer+1, (i*7+k)%width))
+			}
+		}
+	}
+	return graph
+}
+
+func TestParallelMatchesSerial(t *testing.T) {
+	graph := syntheticGraph(8, 100)
+
+	if !reflect.DeepEqual(graph.BlastRadius(), graph.BlastRadiusParallel(4)) {
+		t.Error("BlastRadiusParallel() should match BlastRadius()")
+	}
+	if !reflect.DeepEqual(graph.TransitiveClosure(), graph.TransitiveClosureParallel(4)) {
+		t.Error("TransitiveClosureParallel() should match TransitiveClosure()")
+	}
+}
+
+func BenchmarkBlastRadius(b *testing.B) {
+	graph := syntheticGraph(10, 100)
+
+	b.Run("serial", func(b *testing.B) {
+		for b.Loop() {
+			graph.BlastRadius()
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for b.Loop() {
+			graph.BlastRadiusParallel(8)
+		}
+	})
+}
+
+func BenchmarkTransitiveClosure(b *testing.B) {
+	graph := syntheticGraph(10, 100)
+
+	b.Run("serial", func(b *testing.B) {
+		for b.Loop() {
+			graph.TransitiveClosure()
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for b.Loop() {
+			graph.TransitiveClosureParallel(8)
+		}
+	})
+}
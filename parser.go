@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // ParseError represents an error that occurred during parsing
@@ -19,22 +21,28 @@ func (pe ParseError) Error() string {
 	return fmt.Sprintf("parse error at line %d (%q): %v", pe.Line, pe.Content, pe.Err)
 }
 
-// parseModule parses a module string into a Module struct
+// parseModule parses a module string into a Module struct. Surrounding
+// whitespace and trailing slashes on the path are removed so equivalent
+// tokens yield the same module; whitespace or invisible formatting
+// characters inside the token are rejected.
 func parseModule(moduleStr string) (Module, error) {
+	moduleStr = strings.TrimSpace(moduleStr)
 	if moduleStr == "" {
 		return Module{}, fmt.Errorf("empty module string")
 	}
-
-	// Find the last @ symbol to separate path from version
-	lastAt := strings.LastIndex(moduleStr, "@")
-	if lastAt == -1 {
-		// No version (main module)
-		return Module{Path: moduleStr, Version: ""}, nil
+	if idx := strings.IndexFunc(moduleStr, isStrayRune); idx != -1 {
+		r, _ := utf8.DecodeRuneInString(moduleStr[idx:])
+		return Module{}, fmt.Errorf("module string contains whitespace or invisible character %U", r)
 	}
 
-	path := moduleStr[:lastAt]
-	version := moduleStr[lastAt+1:]
+	// Find the last @ symbol to separate path from version; if what follows
+	// does not look like a version, the @ is part of the path
+	path, version := moduleStr, ""
+	if lastAt := strings.LastIndex(moduleStr, "@"); lastAt != -1 && isLikelyVersion(moduleStr[lastAt+1:]) {
+		path, version = moduleStr[:lastAt], moduleStr[lastAt+1:]
+	}
 
+	path = strings.TrimRight(path, "/")
 	if path == "" {
 		return Module{}, fmt.Errorf("empty module path")
 	}
@@ -42,6 +50,30 @@ func parseModule(moduleStr string) (Module, error) {
 	return Module{Path: path, Version: version}, nil
 }
 
+// isStrayRune reports whether r is whitespace or an invisible formatting
+// character such as a zero-width space, neither of which belongs in a module string
+func isStrayRune(r rune) bool {
+	return unicode.IsSpace(r) || unicode.Is(unicode.Cf, r)
+}
+
+// isLikelyVersion reports whether s looks like a module version: a semantic
+// or pseudo-version starting with "v", a toolchain version such as "go1.21.0",
+// or a bare numeric or date form such as "1.21". Versions never contain a slash.
+func isLikelyVersion(s string) bool {
+	if s == "" || strings.ContainsAny(s, "/ \t") {
+		return false
+	}
+
+	rest := s
+	switch {
+	case strings.HasPrefix(rest, "go"):
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "v"):
+		rest = rest[1:]
+	}
+	return rest != "" && rest[0] >= '0' && rest[0] <= '9'
+}
+
 // ParseGraphFromFile parses a go mod graph file and returns a DependencyGraph
 func ParseGraphFromFile(filename string) (*DependencyGraph, error) {
 	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line argument
@@ -55,14 +87,63 @@ func ParseGraphFromFile(filename string) (*DependencyGraph, error) {
 
 // ParseGraph parses go mod graph output from a reader and returns a DependencyGraph
 func ParseGraph(reader io.Reader) (*DependencyGraph, error) {
+	// First pass: collect all dependencies
+	dependencies, err := parseDependencies(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dependencies) == 0 {
+		return nil, fmt.Errorf("no dependencies found in input")
+	}
+
+	// Second pass: identify the main module
+	// The main module is the one without a version that appears as a "from" dependency
+	mainModule := identifyMainModule(dependencies)
+
+	// Create graph with correct main module
+	graph := NewDependencyGraph(mainModule)
+
+	// Add all dependencies
+	for _, dep := range dependencies {
+		graph.addEdge(dep)
+	}
+
+	return graph, nil
+}
+
+// AppendFromReader parses additional go mod graph lines and merges their
+// edges into the graph, keeping the existing main module
+func (dg *DependencyGraph) AppendFromReader(reader io.Reader) error {
+	dependencies, err := parseDependencies(reader)
+	if err != nil {
+		return err
+	}
+
+	for _, dep := range dependencies {
+		dg.addEdge(dep)
+	}
+	return nil
+}
+
+// byteOrderMark is the UTF-8 encoding of U+FEFF, which some editors write at
+// the start of a file
+const byteOrderMark = "\uFEFF"
+
+// parseDependencies reads "from to" lines from go mod graph output
+func parseDependencies(reader io.Reader) ([]Dependency, error) {
 	scanner := bufio.NewScanner(reader)
 	var dependencies []Dependency
 	lineNum := 0
 
-	// First pass: collect all dependencies
 	for scanner.Scan() {
 		lineNum++
-		line := strings.TrimSpace(scanner.Text())
+		text := scanner.Text()
+		if lineNum == 1 {
+			// Files saved on Windows may start with a UTF-8 byte order mark
+			text = strings.TrimPrefix(text, byteOrderMark)
+		}
+		line := strings.TrimSpace(text)
 
 		// Skip empty lines
 		if line == "" {
@@ -97,30 +178,48 @@ func ParseGraph(reader io.Reader) (*DependencyGraph, error) {
 			}
 		}
 
-		dependencies = append(dependencies, Dependency{From: fromModule, To: toModule})
+		dependencies = append(dependencies, Dependency{From: fromModule, To: toModule, SourceLine: lineNum})
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading input: %w", err)
 	}
 
-	if len(dependencies) == 0 {
-		return nil, fmt.Errorf("no dependencies found in input")
-	}
+	return dependencies, nil
+}
 
-	// Second pass: identify the main module
-	// The main module is the one without a version that appears as a "from" dependency
-	mainModule := identifyMainModule(dependencies)
+// parsePairs reads "key value" lines into a map. Blank lines and lines
+// starting with # are ignored; later duplicates overwrite earlier ones.
+func parsePairs(reader io.Reader) (map[string]string, error) {
+	scanner := bufio.NewScanner(reader)
+	pairs := make(map[string]string)
+	lineNum := 0
 
-	// Create graph with correct main module
-	graph := NewDependencyGraph(mainModule)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
 
-	// Add all dependencies
-	for _, dep := range dependencies {
-		graph.AddDependency(dep.From, dep.To)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			return nil, ParseError{
+				Line:    lineNum,
+				Content: line,
+				Err:     fmt.Errorf("expected 2 fields, got %d", len(parts)),
+			}
+		}
+
+		pairs[parts[0]] = parts[1]
 	}
 
-	return graph, nil
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+
+	return pairs, nil
 }
 
 // identifyMainModule identifies the main module from the dependencies
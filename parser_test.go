@@ -26,6 +26,16 @@ func TestParseModule(t *testing.T) {
 			expected: Module{Path: "github.com/example@test/module", Version: "v1.2.3"},
 			wantErr:  false,
 		},
+		{
+			input:    "github.com/example/module@branch/feature",
+			expected: Module{Path: "github.com/example/module@branch/feature", Version: ""},
+			wantErr:  false,
+		},
+		{
+			input:    "toolchain@go1.21.0",
+			expected: Module{Path: "toolchain", Version: "go1.21.0"},
+			wantErr:  false,
+		},
 		{
 			input:   "",
 			wantErr: true,
@@ -34,6 +44,30 @@ func TestParseModule(t *testing.T) {
 			input:   "@v1.2.3",
 			wantErr: true,
 		},
+		{
+			input:    "github.com/foo/",
+			expected: Module{Path: "github.com/foo", Version: ""},
+		},
+		{
+			input:    "github.com/foo/@v1.2.3",
+			expected: Module{Path: "github.com/foo", Version: "v1.2.3"},
+		},
+		{
+			input:    " github.com/foo@v1.2.3\t",
+			expected: Module{Path: "github.com/foo", Version: "v1.2.3"},
+		},
+		{
+			input:   "/",
+			wantErr: true,
+		},
+		{
+			input:   "github.com/foo\u200b@v1.2.3",
+			wantErr: true,
+		},
+		{
+			input:   "github.com/foo bar",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -50,6 +84,64 @@ func TestParseModule(t *testing.T) {
 	}
 }
 
+func TestParseGraph_NormalizesPaths(t *testing.T) {
+	input := "github.com/example/main github.com/foo/@v1.0.0\ngithub.com/example/main/ github.com/foo@v1.0.0\n"
+
+	graph, err := ParseGraph(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+	if modules := graph.GetAllModules(); len(modules) != 2 {
+		t.Errorf("modules = %v, want trailing slashes to collapse into 2 modules", modules)
+	}
+}
+
+func TestParseGraph_ByteOrderMark(t *testing.T) {
+	input := "\uFEFFgithub.com/example/main github.com/dep1@v1.0.0\r\ngithub.com/dep1@v1.0.0 github.com/subdep@v1.0.0\r\n"
+
+	graph, err := ParseGraph(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+	if want := (Module{Path: "github.com/example/main"}); graph.MainModule != want {
+		t.Errorf("MainModule = %#v, want %#v without the byte order mark", graph.MainModule, want)
+	}
+	if got := graph.Dependencies[1].To; got != (Module{Path: "github.com/subdep", Version: "v1.0.0"}) {
+		t.Errorf("second edge target = %#v, want CRLF endings stripped", got)
+	}
+
+	if _, err := ParseGraph(strings.NewReader("github.com/example/main \uFEFFgithub.com/dep1@v1.0.0\n")); err == nil {
+		t.Error("ParseGraph() should still reject a byte order mark inside a line")
+	}
+}
+
+func TestIsLikelyVersion(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "v1.2.3", want: true},
+		{input: "v0.0.0-20230316100256-276c6243b2f6", want: true},
+		{input: "v2.0.0+incompatible", want: true},
+		{input: "go1.21.0", want: true},
+		{input: "1.21", want: true},
+		{input: "20230316", want: true},
+		{input: "", want: false},
+		{input: "v", want: false},
+		{input: "latest", want: false},
+		{input: "v1.0.0/sub", want: false},
+		{input: "branch/feature", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := isLikelyVersion(tt.input); got != tt.want {
+				t.Errorf("isLikelyVersion(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseGraph(t *testing.T) {
 	input := `github.com/example/main github.com/dep1@v1.0.0
 github.com/example/main github.com/dep2@v2.0.0
@@ -79,6 +171,30 @@ github.com/dep1@v1.0.0 github.com/subdep@v1.0.0`
 	}
 }
 
+func TestParseGraph_SourceLines(t *testing.T) {
+	input := `github.com/example/main github.com/dep1@v1.0.0
+
+github.com/example/main github.com/dep2@v2.0.0
+github.com/dep1@v1.0.0 github.com/subdep@v1.0.0`
+
+	graph, err := ParseGraph(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+
+	want := []int{1, 3, 4}
+	for i, dep := range graph.Dependencies {
+		if dep.SourceLine != want[i] {
+			t.Errorf("Dependencies[%d].SourceLine = %d, want %d", i, dep.SourceLine, want[i])
+		}
+	}
+
+	graph.AddDependency(Module{Path: "github.com/example/main"}, Module{Path: "github.com/dep3", Version: "v1.0.0"})
+	if line := graph.Dependencies[len(graph.Dependencies)-1].SourceLine; line != 0 {
+		t.Errorf("programmatic edge SourceLine = %d, want 0", line)
+	}
+}
+
 func TestParseGraphErrors(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -168,3 +284,37 @@ func TestModule_String(t *testing.T) {
 		})
 	}
 }
+
+func TestDependencyGraph_AppendFromReader(t *testing.T) {
+	graph, err := ParseGraph(strings.NewReader("github.com/example/main github.com/dep1@v1.0.0"))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+
+	// Build the cached tree before appending so invalidation is exercised
+	if len(graph.GetTree()["github.com/dep1@v1.0.0"]) != 0 {
+		t.Fatal("dep1 should have no children before appending")
+	}
+
+	more := `github.com/dep1@v1.0.0 github.com/subdep@v1.0.0
+github.com/example/main github.com/dep2@v2.0.0`
+	if err := graph.AppendFromReader(strings.NewReader(more)); err != nil {
+		t.Fatalf("AppendFromReader() error = %v", err)
+	}
+
+	if len(graph.Dependencies) != 3 {
+		t.Errorf("Dependencies length = %d, want 3", len(graph.Dependencies))
+	}
+	if graph.MainModule.Path != "github.com/example/main" {
+		t.Errorf("MainModule = %v, want github.com/example/main", graph.MainModule)
+	}
+
+	tree := graph.GetTree()
+	if len(tree["github.com/dep1@v1.0.0"]) != 1 || len(tree["github.com/example/main"]) != 2 {
+		t.Errorf("GetTree() should reflect appended edges, got %v", tree)
+	}
+
+	if err := graph.AppendFromReader(strings.NewReader("not-a-valid-line")); err == nil {
+		t.Error("AppendFromReader() should reject malformed lines")
+	}
+}
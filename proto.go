@@ -0,0 +1,71 @@
+package tangled
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative proto/tangled.proto
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	tangledpb "github.com/scottbrown/tangled/proto"
+)
+
+// MarshalProto encodes the graph as a tangled.Graph protobuf message, as
+// defined in proto/tangled.proto. Modules are stored once in sorted order and
+// edges refer to them by index.
+func (dg *DependencyGraph) MarshalProto() ([]byte, error) {
+	modules := dg.GetAllModules()
+	index := make(map[string]uint32, len(modules))
+	message := &tangledpb.Graph{Modules: make([]*tangledpb.Module, len(modules))}
+	for i, module := range modules {
+		index[module.String()] = uint32(i) // #nosec G115 -- module counts are far below 2^32
+		message.Modules[i] = &tangledpb.Module{Path: module.Path, Version: module.Version}
+	}
+
+	message.Main = index[dg.MainModule.String()]
+	for _, dep := range dg.Dependencies {
+		message.Edges = append(message.Edges, &tangledpb.Edge{
+			From: index[dep.From.String()],
+			To:   index[dep.To.String()],
+		})
+	}
+
+	return proto.Marshal(message)
+}
+
+// UnmarshalProto replaces the graph with one decoded from a tangled.Graph
+// protobuf message produced by MarshalProto. Unknown fields are ignored.
+func (dg *DependencyGraph) UnmarshalProto(data []byte) error {
+	var message tangledpb.Graph
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return fmt.Errorf("invalid protobuf: %w", err)
+	}
+
+	module := func(i uint32) (Module, error) {
+		if int(i) >= len(message.Modules) {
+			return Module{}, fmt.Errorf("module index %d out of range", i)
+		}
+		m := message.Modules[i]
+		return Module{Path: m.GetPath(), Version: m.GetVersion()}, nil
+	}
+
+	mainModule, err := module(message.GetMain())
+	if err != nil {
+		return fmt.Errorf("invalid main module: %w", err)
+	}
+	graph := NewDependencyGraph(mainModule)
+	for _, edge := range message.Edges {
+		from, err := module(edge.GetFrom())
+		if err != nil {
+			return fmt.Errorf("invalid edge: %w", err)
+		}
+		to, err := module(edge.GetTo())
+		if err != nil {
+			return fmt.Errorf("invalid edge: %w", err)
+		}
+		graph.AddDependency(from, to)
+	}
+
+	*dg = *graph
+	return nil
+}
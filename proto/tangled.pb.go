@@ -0,0 +1,260 @@
+// Wire format used by DependencyGraph.MarshalProto and UnmarshalProto.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: proto/tangled.proto
+
+package tangledpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Module is a single Go module.
+type Module struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // empty for the main module
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Module) Reset() {
+	*x = Module{}
+	mi := &file_proto_tangled_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Module) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Module) ProtoMessage() {}
+
+func (x *Module) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tangled_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Module.ProtoReflect.Descriptor instead.
+func (*Module) Descriptor() ([]byte, []int) {
+	return file_proto_tangled_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Module) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Module) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Edge is a dependency between two modules, referenced by their index in
+// Graph.modules.
+type Edge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          uint32                 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            uint32                 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	mi := &file_proto_tangled_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tangled_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_proto_tangled_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Edge) GetFrom() uint32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *Edge) GetTo() uint32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// Graph is a complete dependency graph.
+type Graph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Main          uint32                 `protobuf:"varint,1,opt,name=main,proto3" json:"main,omitempty"` // index of the main module in modules
+	Modules       []*Module              `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty"`
+	Edges         []*Edge                `protobuf:"bytes,3,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Graph) Reset() {
+	*x = Graph{}
+	mi := &file_proto_tangled_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Graph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Graph) ProtoMessage() {}
+
+func (x *Graph) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tangled_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Graph.ProtoReflect.Descriptor instead.
+func (*Graph) Descriptor() ([]byte, []int) {
+	return file_proto_tangled_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Graph) GetMain() uint32 {
+	if x != nil {
+		return x.Main
+	}
+	return 0
+}
+
+func (x *Graph) GetModules() []*Module {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *Graph) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+var File_proto_tangled_proto protoreflect.FileDescriptor
+
+const file_proto_tangled_proto_rawDesc = "" +
+	"\n" +
+	"\x13proto/tangled.proto\x12\atangled\"6\n" +
+	"\x06Module\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"*\n" +
+	"\x04Edge\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\rR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\rR\x02to\"k\n" +
+	"\x05Graph\x12\x12\n" +
+	"\x04main\x18\x01 \x01(\rR\x04main\x12)\n" +
+	"\amodules\x18\x02 \x03(\v2\x0f.tangled.ModuleR\amodules\x12#\n" +
+	"\x05edges\x18\x03 \x03(\v2\r.tangled.EdgeR\x05edgesB/Z-github.com/scottbrown/tangled/proto;tangledpbb\x06proto3"
+
+var (
+	file_proto_tangled_proto_rawDescOnce sync.Once
+	file_proto_tangled_proto_rawDescData []byte
+)
+
+func file_proto_tangled_proto_rawDescGZIP() []byte {
+	file_proto_tangled_proto_rawDescOnce.Do(func() {
+		file_proto_tangled_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_tangled_proto_rawDesc), len(file_proto_tangled_proto_rawDesc)))
+	})
+	return file_proto_tangled_proto_rawDescData
+}
+
+var file_proto_tangled_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_tangled_proto_goTypes = []any{
+	(*Module)(nil), // 0: tangled.Module
+	(*Edge)(nil),   // 1: tangled.Edge
+	(*Graph)(nil),  // 2: tangled.Graph
+}
+var file_proto_tangled_proto_depIdxs = []int32{
+	0, // 0: tangled.Graph.modules:type_name -> tangled.Module
+	1, // 1: tangled.Graph.edges:type_name -> tangled.Edge
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_tangled_proto_init() }
+func file_proto_tangled_proto_init() {
+	if File_proto_tangled_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tangled_proto_rawDesc), len(file_proto_tangled_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_tangled_proto_goTypes,
+		DependencyIndexes: file_proto_tangled_proto_depIdxs,
+		MessageInfos:      file_proto_tangled_proto_msgTypes,
+	}.Build()
+	File_proto_tangled_proto = out.File
+	file_proto_tangled_proto_goTypes = nil
+	file_proto_tangled_proto_depIdxs = nil
+}
@@ -0,0 +1,51 @@
+package tangled
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependencyGraph_ProtoRoundTrip(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(Module{Path: "github.com/subdep", Version: "v1.0.0"}, Module{Path: "github.com/dep1", Version: "v1.0.0"})
+
+	data, err := graph.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto() error = %v", err)
+	}
+
+	var decoded DependencyGraph
+	if err := decoded.UnmarshalProto(data); err != nil {
+		t.Fatalf("UnmarshalProto() error = %v", err)
+	}
+
+	if decoded.MainModule != graph.MainModule {
+		t.Errorf("MainModule = %v, want %v", decoded.MainModule, graph.MainModule)
+	}
+	if !reflect.DeepEqual(decoded.GetAllModules(), graph.GetAllModules()) {
+		t.Errorf("modules = %v, want %v", decoded.GetAllModules(), graph.GetAllModules())
+	}
+	if !reflect.DeepEqual(decoded.EdgeCounts(), graph.EdgeCounts()) {
+		t.Errorf("edges = %v, want %v", decoded.EdgeCounts(), graph.EdgeCounts())
+	}
+}
+
+func TestDependencyGraph_UnmarshalProtoErrors(t *testing.T) {
+	data, err := createTestGraph().MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto() error = %v", err)
+	}
+
+	tests := map[string][]byte{
+		"truncated":          data[:len(data)-1],
+		"index out of range": {0x1a, 0x02, 0x10, 0x63}, // edges { to: 99 } with no modules
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			var graph DependencyGraph
+			if err := graph.UnmarshalProto(input); err == nil {
+				t.Error("UnmarshalProto() should fail")
+			}
+		})
+	}
+}
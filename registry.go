@@ -0,0 +1,158 @@
+package tangled
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Format describes a registered output format: the names it is selected by,
+// how its output is written to files, and how to create its renderer
+type Format struct {
+	Names  []string        // canonical name first, followed by aliases
+	Suffix string          // file name suffix, such as ".dot", for output written to a directory
+	Binary bool            // output is not text
+	Target bool            // the renderer needs a target module, as WhyRenderer does
+	New    func() Renderer // creates a new renderer for each use
+}
+
+// registry holds the registered formats in registration order and indexes
+// them by lowercase name and alias
+var registry = struct {
+	sync.RWMutex
+	formats []*Format
+	byName  map[string]*Format
+}{byName: make(map[string]*Format)}
+
+// RegisterFormat makes a format available under each of its names,
+// case-insensitively, so callers such as the CLI can list it with Formats
+// and create its renderer with LookupRenderer. A name already taken by
+// another format is moved to this one, and a format left without names is
+// dropped. It panics if the format has no names or New is nil.
+func RegisterFormat(format Format) {
+	if len(format.Names) == 0 {
+		panic("tangled: RegisterFormat format has no names")
+	}
+	if format.New == nil {
+		panic("tangled: RegisterFormat New is nil for " + format.Names[0])
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	addFormat(format)
+}
+
+// addFormat registers a format with the registry lock held
+func addFormat(format Format) {
+	registered := format
+	registered.Names = make([]string, len(format.Names))
+	for i, name := range format.Names {
+		registered.Names[i] = strings.ToLower(name)
+	}
+
+	for _, name := range registered.Names {
+		if previous, ok := registry.byName[name]; ok {
+			previous.Names = slices.DeleteFunc(previous.Names, func(n string) bool { return n == name })
+		}
+		registry.byName[name] = &registered
+	}
+	registry.formats = slices.DeleteFunc(registry.formats, func(f *Format) bool { return len(f.Names) == 0 })
+	registry.formats = append(registry.formats, &registered)
+}
+
+// RegisterRenderer makes a renderer available under name, case-insensitively.
+// Registering a name that is already taken replaces the renderer of that
+// format, aliases included, and keeps its suffix and other details, which
+// lets third parties override a built-in renderer. A new name becomes a text
+// format written with the suffix "." + name. It panics if factory is nil.
+func RegisterRenderer(name string, factory func() Renderer) {
+	if factory == nil {
+		panic("tangled: RegisterRenderer factory is nil for " + name)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if format, ok := registry.byName[strings.ToLower(name)]; ok {
+		format.New = factory
+		return
+	}
+	addFormat(Format{Names: []string{name}, Suffix: "." + strings.ToLower(name), New: factory})
+}
+
+// LookupRenderer creates a new renderer registered under name,
+// case-insensitively, with ok false if no renderer has that name
+func LookupRenderer(name string) (Renderer, bool) {
+	format, ok := LookupFormat(name)
+	if !ok {
+		return nil, false
+	}
+	return format.New(), true
+}
+
+// LookupFormat returns the format registered under name or one of its
+// aliases, case-insensitively
+func LookupFormat(name string) (Format, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	format, ok := registry.byName[strings.ToLower(name)]
+	if !ok {
+		return Format{}, false
+	}
+	return format.copy(), true
+}
+
+// Formats returns every registered format in registration order, built-in
+// formats first
+func Formats() []Format {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	formats := make([]Format, len(registry.formats))
+	for i, format := range registry.formats {
+		formats[i] = format.copy()
+	}
+	return formats
+}
+
+// RendererNames returns every registered renderer name, aliases included, in
+// sorted order
+func RendererNames() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.byName))
+	for name := range registry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// copy returns the format with its own copy of the names, so callers cannot
+// change the registry
+func (f *Format) copy() Format {
+	format := *f
+	format.Names = slices.Clone(f.Names)
+	return format
+}
+
+func init() {
+	RegisterFormat(Format{Names: []string{"text", "plaintext", "tree"}, Suffix: ".txt", New: func() Renderer { return NewPlaintextRenderer() }})
+	RegisterFormat(Format{Names: []string{"html", "d3"}, Suffix: ".html", New: func() Renderer { return NewHTMLRenderer() }})
+	RegisterFormat(Format{Names: []string{"mermaid", "mmd"}, Suffix: ".mmd", New: func() Renderer { return NewMermaidRenderer() }})
+	RegisterFormat(Format{Names: []string{"dot", "graphviz"}, Suffix: ".dot", New: func() Renderer { return NewGraphvizRenderer() }})
+	RegisterFormat(Format{Names: []string{"summary"}, Suffix: ".summary.txt", New: func() Renderer { return NewSummaryRenderer() }})
+	RegisterFormat(Format{Names: []string{"csv"}, Suffix: ".csv", New: func() Renderer { return NewCSVRenderer() }})
+	RegisterFormat(Format{Names: []string{"csv-edges"}, Suffix: ".edges.csv", New: func() Renderer { return &CSVRenderer{Edges: true} }})
+	RegisterFormat(Format{Names: []string{"json"}, Suffix: ".json", New: func() Renderer { return NewJSONRenderer() }})
+	RegisterFormat(Format{Names: []string{"dgml"}, Suffix: ".dgml", New: func() Renderer { return NewDGMLRenderer() }})
+	RegisterFormat(Format{Names: []string{"protobuf", "pb"}, Suffix: ".pb", Binary: true, New: func() Renderer { return NewProtobufRenderer() }})
+	RegisterFormat(Format{Names: []string{"cypher", "neo4j"}, Suffix: ".cypher", New: func() Renderer { return NewCypherRenderer() }})
+	RegisterFormat(Format{Names: []string{"why"}, Suffix: ".why.txt", Target: true, New: func() Renderer { return NewWhyRenderer("") }})
+	RegisterFormat(Format{Names: []string{"modules", "list"}, Suffix: ".modules.txt", New: func() Renderer { return NewModuleListRenderer() }})
+	RegisterFormat(Format{Names: []string{"folded"}, Suffix: ".folded", New: func() Renderer { return NewFoldedRenderer() }})
+	RegisterFormat(Format{Names: []string{"htmltable"}, Suffix: ".table.html", New: func() Renderer { return NewHTMLTableRenderer() }})
+	RegisterFormat(Format{Names: []string{"yamltree"}, Suffix: ".yaml", New: func() Renderer { return NewYAMLTreeRenderer() }})
+}
@@ -0,0 +1,81 @@
+package tangled
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// stubRenderer writes a fixed string, standing in for a third-party renderer
+type stubRenderer struct{}
+
+func (stubRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	_, err := io.WriteString(writer, "stub")
+	return err
+}
+
+func TestRegisterRenderer(t *testing.T) {
+	RegisterRenderer("Test-Stub", func() Renderer { return stubRenderer{} })
+
+	renderer, ok := LookupRenderer("test-stub")
+	if !ok {
+		t.Fatal("LookupRenderer() should find a custom renderer, case-insensitively")
+	}
+	var buf bytes.Buffer
+	if err := renderer.Render(createTestGraph(), &buf); err != nil || buf.String() != "stub" {
+		t.Errorf("Render() = %q, %v, want the custom renderer's output", buf.String(), err)
+	}
+
+	if _, ok := LookupRenderer("no-such-renderer"); ok {
+		t.Error("LookupRenderer() should fail for unregistered names")
+	}
+}
+
+func TestRegisterRenderer_Builtins(t *testing.T) {
+	for _, name := range []string{"text", "tree", "html", "mermaid", "dot", "csv-edges", "json", "protobuf", "cypher", "why", "modules"} {
+		if _, ok := LookupRenderer(name); !ok {
+			t.Errorf("built-in renderer %q is not registered", name)
+		}
+	}
+	if renderer, _ := LookupRenderer("csv-edges"); !renderer.(*CSVRenderer).Edges {
+		t.Error("csv-edges should create an edge-list CSVRenderer")
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat(Format{Names: []string{"Test-Format", "test-alias"}, Suffix: ".test", Binary: true, New: func() Renderer { return stubRenderer{} }})
+
+	format, ok := LookupFormat("test-alias")
+	if !ok || format.Names[0] != "test-format" || format.Suffix != ".test" || !format.Binary {
+		t.Fatalf("LookupFormat() = %+v, %v, want the registered format by alias", format, ok)
+	}
+	if last := Formats()[len(Formats())-1]; last.Names[0] != "test-format" {
+		t.Errorf("Formats() should list formats in registration order, last is %v", last.Names)
+	}
+
+	// Replacing the renderer keeps the format's details
+	RegisterRenderer("test-alias", func() Renderer { return NewSummaryRenderer() })
+	if format, _ := LookupFormat("test-format"); format.Suffix != ".test" || !format.Binary {
+		t.Errorf("RegisterRenderer() should keep the format details, got %+v", format)
+	}
+	if renderer, _ := LookupRenderer("test-format"); renderer == nil {
+		t.Fatal("LookupRenderer() should find the format")
+	} else if _, ok := renderer.(*SummaryRenderer); !ok {
+		t.Errorf("LookupRenderer() = %T, want the replacement renderer", renderer)
+	}
+
+	// A name registered again moves to the new format
+	RegisterFormat(Format{Names: []string{"test-alias"}, Suffix: ".alias", New: func() Renderer { return stubRenderer{} }})
+	if format, _ := LookupFormat("test-format"); len(format.Names) != 1 {
+		t.Errorf("test-format should lose its alias, got %v", format.Names)
+	}
+	if format, _ := LookupFormat("test-alias"); format.Suffix != ".alias" {
+		t.Errorf("test-alias should belong to the new format, got %+v", format)
+	}
+
+	// Callers get copies they cannot use to change the registry
+	Formats()[0].Names[0] = "changed"
+	if _, ok := LookupFormat("changed"); ok || Formats()[0].Names[0] != "text" {
+		t.Error("Formats() should return copies of the registered formats")
+	}
+}
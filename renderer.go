@@ -1,10 +1,19 @@
 package tangled
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"hash/fnv"
+	"html"
 	"io"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // Renderer interface for different output formats
@@ -18,8 +27,143 @@ type FileAwareRenderer interface {
 	RenderWithFilename(graph *DependencyGraph, writer io.Writer, filename string) error
 }
 
+// RenderOptions holds settings shared by all renderers
+type RenderOptions struct {
+	// Labels formats node labels; nil uses the module string
+	Labels *LabelTemplate
+
+	// Aliases maps module paths to friendly display names; node IDs and
+	// tooltips keep the real path
+	Aliases map[string]string
+
+	// Links attaches a pkg.go.dev URL to each node in formats that support
+	// hyperlinks
+	Links bool
+
+	// Prefix and Suffix are written verbatim before and after the rendered
+	// output, e.g. to wrap it in a fenced code block
+	Prefix string
+	Suffix string
+
+	// Header starts text, DOT and Mermaid output with a comment line giving
+	// the module and edge counts, and Generated when it is set
+	Header    bool
+	Generated time.Time
+
+	// DroppedEdges is how many edges were removed to limit the graph's size,
+	// as LimitEdges reports. When non-zero, text, DOT and Mermaid output
+	// start with a comment line recording it, as part of the Header line
+	// when that is set, so saved output shows it was truncated.
+	DroppedEdges int
+
+	// Options holds renderer-specific settings as key/value pairs, such as
+	// rankdir for DOT or charge for HTML. Renderers ignore keys they do not
+	// use, so one set of options can be passed to every format; those that
+	// read any implement OptionKeyer to list them.
+	Options map[string]string
+}
+
+// SetRenderOptions replaces the renderer's shared options
+func (o *RenderOptions) SetRenderOptions(opts RenderOptions) {
+	*o = opts
+}
+
+// wrapOutput writes the prefix, runs render and then writes the suffix
+func (o *RenderOptions) wrapOutput(writer io.Writer, render func() error) error {
+	if _, err := io.WriteString(writer, o.Prefix); err != nil {
+		return err
+	}
+	if err := render(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, o.Suffix)
+	return err
+}
+
+// writeHeader writes the Header comment line using the format's comment
+// marker, doing nothing when Header is unset and no edges were dropped
+func (o *RenderOptions) writeHeader(writer io.Writer, graph *DependencyGraph, comment string) error {
+	var fields []string
+	if o.Header {
+		stats := graph.Stats()
+		fields = append(fields, fmt.Sprintf("modules: %d, edges: %d", stats.Modules, stats.Edges))
+		if !o.Generated.IsZero() {
+			fields = append(fields, "generated: "+o.Generated.Format(time.RFC3339))
+		}
+	}
+	if o.DroppedEdges > 0 {
+		fields = append(fields, fmt.Sprintf("dropped edges: %d", o.DroppedEdges))
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	_, err := fmt.Fprintln(writer, comment+" "+strings.Join(fields, ", "))
+	return err
+}
+
+// moduleURL returns the pkg.go.dev documentation URL for a module
+func moduleURL(module Module) string {
+	return "https://pkg.go.dev/" + module.String()
+}
+
+// Configurable is implemented by renderers that accept shared RenderOptions
+type Configurable interface {
+	SetRenderOptions(opts RenderOptions)
+}
+
+// OptionKeyer is implemented by renderers that read renderer-specific
+// settings from RenderOptions.Options, so callers can reject unknown keys
+type OptionKeyer interface {
+	OptionKeys() []string
+}
+
+// TreeCharset holds the glyphs used to draw plaintext tree connectors
+type TreeCharset struct {
+	Branch        string // connector for a child with later siblings
+	Last          string // connector for the final child
+	Vertical      string // continuation line beside later siblings
+	PathSeparator string // separator between modules in full paths
+}
+
+// UnicodeCharset draws trees with box-drawing characters
+var UnicodeCharset = TreeCharset{Branch: "├── ", Last: "└── ", Vertical: "│   ", PathSeparator: " → "}
+
+// ASCIICharset draws trees with plain ASCII for terminals without box-drawing support
+var ASCIICharset = TreeCharset{Branch: "+-- ", Last: "`-- ", Vertical: "|   ", PathSeparator: " > "}
+
 // PlaintextRenderer renders the dependency graph as plaintext tree
-type PlaintextRenderer struct{}
+type PlaintextRenderer struct {
+	RenderOptions
+
+	// Charset selects the connector glyphs; the zero value uses UnicodeCharset
+	Charset TreeCharset
+
+	// FullPaths prints the whole root-to-node path on each line instead of
+	// just the node, marking nodes that close a cycle
+	FullPaths bool
+
+	// Indent is the width of each tree level in characters; zero keeps the
+	// charset's own width
+	Indent int
+
+	// Summarize, when positive, prints only the first Summarize children of
+	// a node with more than that many, followed by a "... and M more" line
+	Summarize int
+
+	// ChildCounts appends the number of distinct direct children to each
+	// node as " (N)"; HideZeroCounts leaves leaves unmarked
+	ChildCounts    bool
+	HideZeroCounts bool
+
+	// ExpandAll repeats a shared module's subtree under every parent instead
+	// of only the first, stopping at cycles and at ExpandDepth levels below
+	// the root (defaultExpandDepth when zero) to keep output bounded
+	ExpandAll   bool
+	ExpandDepth int
+}
+
+// defaultExpandDepth caps ExpandAll trees when ExpandDepth is unset
+const defaultExpandDepth = 10
 
 // NewPlaintextRenderer creates a new plaintext renderer
 func NewPlaintextRenderer() *PlaintextRenderer {
@@ -28,37 +172,156 @@ func NewPlaintextRenderer() *PlaintextRenderer {
 
 // Render renders the dependency graph as a plaintext tree
 func (r *PlaintextRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
-	visited := make(map[string]bool)
-	return r.renderNode(graph, graph.MainModule.String(), "", true, visited, writer)
+	return r.renderTree(graph, graph.GetTree(), graph.MainModule.String(), writer)
+}
+
+// RenderReverse renders a plaintext tree rooted at target that walks up
+// through its dependents, showing everything affected by changing it
+func (r *PlaintextRenderer) RenderReverse(graph *DependencyGraph, target Module, writer io.Writer) error {
+	reverse := make(map[string][]string)
+	for _, dep := range graph.Dependencies {
+		toStr := dep.To.String()
+		reverse[toStr] = append(reverse[toStr], dep.From.String())
+	}
+	return r.renderTree(graph, reverse, target.String(), writer)
+}
+
+// plaintextWalk carries the state of a single plaintext tree rendering
+type plaintextWalk struct {
+	tree    map[string][]string
+	labels  map[string]string
+	visited map[string]bool
+	path    []string // node keys from the root to the current node
+	writer  io.Writer
+	charset TreeCharset
+	blank   string // indentation beneath a final child
+}
+
+func (r *PlaintextRenderer) renderTree(graph *DependencyGraph, tree map[string][]string, root string, writer io.Writer) error {
+	labels, err := nodeLabels(graph, r.Labels, r.Aliases)
+	if err != nil {
+		return err
+	}
+
+	charset := r.charset()
+	width := utf8.RuneCountInString(charset.Vertical)
+	if r.Indent > 0 {
+		width = r.Indent
+		charset.Branch = fitConnector(charset.Branch, width)
+		charset.Last = fitConnector(charset.Last, width)
+		charset.Vertical = fitConnector(charset.Vertical, width)
+	}
+
+	walk := &plaintextWalk{
+		tree:    tree,
+		labels:  labels,
+		visited: make(map[string]bool),
+		writer:  writer,
+		charset: charset,
+		blank:   strings.Repeat(" ", width),
+	}
+	return r.wrapOutput(writer, func() error {
+		if err := r.writeHeader(writer, graph, "#"); err != nil {
+			return err
+		}
+		return r.renderNode(walk, root, "", true)
+	})
+}
+
+// countDistinct returns the number of distinct strings in keys
+func countDistinct(keys []string) int {
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		seen[key] = true
+	}
+	return len(seen)
 }
 
-func (r *PlaintextRenderer) renderNode(graph *DependencyGraph, nodeKey string, prefix string, isLast bool, visited map[string]bool, writer io.Writer) error {
+// charset returns the configured connector glyphs, defaulting to Unicode
+func (r *PlaintextRenderer) charset() TreeCharset {
+	if r.Charset == (TreeCharset{}) {
+		return UnicodeCharset
+	}
+	return r.Charset
+}
+
+// fitConnector stretches or shrinks a connector glyph to width characters by
+// keeping its first and last characters and repeating the second as filler
+func fitConnector(glyph string, width int) string {
+	runes := []rune(glyph)
+	if len(runes) == width || len(runes) < 2 {
+		return glyph
+	}
+	if width == 1 {
+		return string(runes[0])
+	}
+	return string(runes[0]) + strings.Repeat(string(runes[1]), width-2) + string(runes[len(runes)-1])
+}
+
+func (r *PlaintextRenderer) renderNode(walk *plaintextWalk, nodeKey string, prefix string, isLast bool) error {
 	// Print current node
+	charset := walk.charset
 	var connector string
 	if prefix == "" {
 		connector = ""
 	} else if isLast {
-		connector = "└── "
+		connector = charset.Last
 	} else {
-		connector = "├── "
+		connector = charset.Branch
 	}
 
-	_, err := fmt.Fprintf(writer, "%s%s%s\n", prefix, connector, nodeKey)
+	label := walk.labels[nodeKey]
+	onPath := false
+	for _, key := range walk.path {
+		if key == nodeKey {
+			onPath = true
+			break
+		}
+	}
+
+	walk.path = append(walk.path, nodeKey)
+	defer func() { walk.path = walk.path[:len(walk.path)-1] }()
+
+	if r.FullPaths {
+		segments := make([]string, len(walk.path))
+		for i, key := range walk.path {
+			segments[i] = walk.labels[key]
+		}
+		label = strings.Join(segments, charset.PathSeparator)
+		if onPath {
+			label += " (cycle)"
+		}
+	}
+	if r.ChildCounts {
+		if n := countDistinct(walk.tree[nodeKey]); n > 0 || !r.HideZeroCounts {
+			label += fmt.Sprintf(" (%d)", n)
+		}
+	}
+
+	_, err := fmt.Fprintf(walk.writer, "%s%s%s\n", prefix, connector, label)
 	if err != nil {
 		return err
 	}
 
-	// Avoid infinite recursion by tracking visited nodes
-	if visited[nodeKey] {
-		return nil
+	// Avoid infinite recursion: by default each node is expanded only once,
+	// while ExpandAll only stops at cycles and the depth cap
+	if r.ExpandAll {
+		limit := r.ExpandDepth
+		if limit <= 0 {
+			limit = defaultExpandDepth
+		}
+		if onPath || len(walk.path) > limit {
+			return nil
+		}
+	} else {
+		if walk.visited[nodeKey] {
+			return nil
+		}
+		walk.visited[nodeKey] = true
 	}
-	visited[nodeKey] = true
-
-	// Get dependencies for this node
-	tree := graph.GetTree()
-	dependencies := tree[nodeKey]
 
-	// Sort dependencies for consistent output
+	// Sort a copy of the children for consistent output without mutating the tree
+	dependencies := append([]string(nil), walk.tree[nodeKey]...)
 	sort.Strings(dependencies)
 
 	// Calculate new prefix for children
@@ -66,25 +329,43 @@ func (r *PlaintextRenderer) renderNode(graph *DependencyGraph, nodeKey string, p
 	if prefix == "" {
 		newPrefix = "  " // Start indentation for children of root
 	} else if isLast {
-		newPrefix = prefix + "    "
+		newPrefix = prefix + walk.blank
 	} else {
-		newPrefix = prefix + "│   "
+		newPrefix = prefix + charset.Vertical
+	}
+
+	// Fold the remaining children into a summary line when there are too many
+	hidden := 0
+	if r.Summarize > 0 && len(dependencies) > r.Summarize {
+		hidden = len(dependencies) - r.Summarize
+		dependencies = dependencies[:r.Summarize]
 	}
 
 	// Render children
 	for i, dep := range dependencies {
-		isLastChild := i == len(dependencies)-1
-		err := r.renderNode(graph, dep, newPrefix, isLastChild, visited, writer)
+		isLastChild := i == len(dependencies)-1 && hidden == 0
+		err := r.renderNode(walk, dep, newPrefix, isLastChild)
 		if err != nil {
 			return err
 		}
 	}
+	if hidden > 0 {
+		if _, err := fmt.Fprintf(walk.writer, "%s%s... and %d more\n", newPrefix, charset.Last, hidden); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
 // MermaidRenderer renders the dependency graph as MermaidJS format
-type MermaidRenderer struct{}
+type MermaidRenderer struct {
+	RenderOptions
+
+	// GitHub wraps the diagram in a ```mermaid fenced code block so it
+	// renders when pasted into GitHub markdown
+	GitHub bool
+}
 
 // NewMermaidRenderer creates a new MermaidJS renderer
 func NewMermaidRenderer() *MermaidRenderer {
@@ -93,25 +374,53 @@ func NewMermaidRenderer() *MermaidRenderer {
 
 // Render renders the dependency graph as MermaidJS format
 func (r *MermaidRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
-	_, err := fmt.Fprintln(writer, "graph TD")
+	return r.wrapOutput(writer, func() error {
+		if !r.GitHub {
+			return r.render(graph, writer)
+		}
+		if _, err := io.WriteString(writer, "```mermaid\n"); err != nil {
+			return err
+		}
+		if err := r.render(graph, writer); err != nil {
+			return err
+		}
+		_, err := io.WriteString(writer, "```\n")
+		return err
+	})
+}
+
+func (r *MermaidRenderer) render(graph *DependencyGraph, writer io.Writer) error {
+	labels, err := nodeLabels(graph, r.Labels, r.Aliases)
 	if err != nil {
 		return err
 	}
 
-	// Generate unique IDs for nodes
+	if err := r.writeHeader(writer, graph, "%%"); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(writer, "graph TD")
+	if err != nil {
+		return err
+	}
+
+	// Generate unique IDs for nodes, and make sure templated or aliased
+	// labels do not render two modules identically
+	modules := graph.GetAllModules()
+	labels = disambiguateLabels(modules, labels)
 	nodeIDs := make(map[string]string)
 	idCounter := 1
 
-	for _, module := range graph.GetAllModules() {
+	for _, module := range modules {
 		moduleStr := module.String()
 		nodeIDs[moduleStr] = fmt.Sprintf("N%d", idCounter)
 		idCounter++
 	}
 
-	// Render node definitions
-	for moduleStr, nodeID := range nodeIDs {
-		escapedLabel := strings.ReplaceAll(moduleStr, `"`, `\"`)
-		_, err := fmt.Fprintf(writer, "    %s[\"%s\"]\n", nodeID, escapedLabel)
+	// Render node definitions in sorted module order
+	for _, module := range modules {
+		moduleStr := module.String()
+		nodeID := nodeIDs[moduleStr]
+		_, err := fmt.Fprintf(writer, "    %s[\"%s\"]\n", nodeID, escapeMermaidString(labels[moduleStr]))
 		if err != nil {
 			return err
 		}
@@ -121,7 +430,12 @@ func (r *MermaidRenderer) Render(graph *DependencyGraph, writer io.Writer) error
 	for _, dep := range graph.Dependencies {
 		fromID := nodeIDs[dep.From.String()]
 		toID := nodeIDs[dep.To.String()]
-		_, err := fmt.Fprintf(writer, "    %s --> %s\n", fromID, toID)
+		var err error
+		if via := graph.ViaLabel(dep); via != "" {
+			_, err = fmt.Fprintf(writer, "    %s -->|\"%s\"| %s\n", fromID, escapeMermaidString(via), toID)
+		} else {
+			_, err = fmt.Fprintf(writer, "    %s --> %s\n", fromID, toID)
+		}
 		if err != nil {
 			return err
 		}
@@ -131,7 +445,36 @@ func (r *MermaidRenderer) Render(graph *DependencyGraph, writer io.Writer) error
 }
 
 // GraphvizRenderer renders the dependency graph as GraphViz DOT format
-type GraphvizRenderer struct{}
+type GraphvizRenderer struct {
+	RenderOptions
+
+	// WrapLabels breaks labels at path separators once a line reaches this
+	// many characters; zero disables wrapping
+	WrapLabels int
+
+	// RecordNodes draws nodes as records with the path and version in
+	// separate cells, ignoring any label template
+	RecordNodes bool
+
+	// Plain emits only sanitized node IDs and edges with no attributes,
+	// for piping into other DOT processors
+	Plain bool
+
+	// CollapseDuplicates draws repeated edges once, labeled with how many
+	// times they appear in the input
+	CollapseDuplicates bool
+
+	// RelaxBackEdges sets constraint=false on edges pointing back towards
+	// the main module, such as those closing cycles, so they do not pull
+	// modules out of their rank
+	RelaxBackEdges bool
+
+	// RankSep and NodeSep set the graph's ranksep and nodesep attributes, the
+	// spacing in inches between ranks and between nodes in a rank; zero
+	// leaves Graphviz's default
+	RankSep float64
+	NodeSep float64
+}
 
 // NewGraphvizRenderer creates a new GraphViz renderer
 func NewGraphvizRenderer() *GraphvizRenderer {
@@ -140,17 +483,55 @@ func NewGraphvizRenderer() *GraphvizRenderer {
 
 // Render renders the dependency graph as GraphViz DOT format
 func (r *GraphvizRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
-	_, err := fmt.Fprintln(writer, "digraph dependencies {")
+	return r.wrapOutput(writer, func() error {
+		if err := r.writeHeader(writer, graph, "//"); err != nil {
+			return err
+		}
+		return r.render(graph, writer)
+	})
+}
+
+func (r *GraphvizRenderer) render(graph *DependencyGraph, writer io.Writer) error {
+	labels, err := nodeLabels(graph, r.Labels, r.Aliases)
 	if err != nil {
 		return err
 	}
 
-	_, err = fmt.Fprintln(writer, "    rankdir=LR;")
+	_, err = fmt.Fprintln(writer, "digraph dependencies {")
 	if err != nil {
 		return err
 	}
 
-	_, err = fmt.Fprintln(writer, "    node [shape=box, style=rounded];")
+	if r.Plain {
+		return r.renderPlain(graph, writer)
+	}
+
+	rankdir, err := r.rankDir()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "    rankdir=%s;\n", rankdir)
+	if err != nil {
+		return err
+	}
+	if r.RankSep > 0 {
+		if _, err := fmt.Fprintf(writer, "    ranksep=%s;\n", strconv.FormatFloat(r.RankSep, 'g', -1, 64)); err != nil {
+			return err
+		}
+	}
+	if r.NodeSep > 0 {
+		if _, err := fmt.Fprintf(writer, "    nodesep=%s;\n", strconv.FormatFloat(r.NodeSep, 'g', -1, 64)); err != nil {
+			return err
+		}
+	}
+
+	mainStyle := `style="rounded,filled"`
+	if r.RecordNodes {
+		_, err = fmt.Fprintln(writer, "    node [shape=record];")
+		mainStyle = "style=filled"
+	} else {
+		_, err = fmt.Fprintln(writer, "    node [shape=box, style=rounded];")
+	}
 	if err != nil {
 		return err
 	}
@@ -159,14 +540,28 @@ func (r *GraphvizRenderer) Render(graph *DependencyGraph, writer io.Writer) erro
 	modules := graph.GetAllModules()
 	for _, module := range modules {
 		moduleStr := module.String()
-		escapedLabel := strings.ReplaceAll(moduleStr, `"`, `\"`)
+		escapedLabel := r.formatLabel(labels[moduleStr])
+		if r.RecordNodes {
+			escapedLabel = r.recordLabel(aliasModule(module, r.Aliases))
+		}
 		nodeID := r.sanitizeNodeID(moduleStr)
 
+		attrs := fmt.Sprintf("label=\"%s\"", escapedLabel)
+		if module.Vulns != "" {
+			// Outline vulnerable modules in red with the IDs in the tooltip
+			attrs += fmt.Sprintf(", color=\"red\", fontcolor=\"red\", tooltip=\"%s\"", escapeDOTString(moduleStr+": "+module.Vulns))
+		} else if _, ok := r.Aliases[module.Path]; ok {
+			attrs += fmt.Sprintf(", tooltip=\"%s\"", escapeDOTString(moduleStr))
+		}
+		if r.Links {
+			attrs += fmt.Sprintf(", URL=\"%s\"", escapeDOTString(moduleURL(module)))
+		}
+
 		// Highlight main module
 		if moduleStr == graph.MainModule.String() {
-			_, err = fmt.Fprintf(writer, "    \"%s\" [label=\"%s\", fillcolor=lightblue, style=\"rounded,filled\"];\n", nodeID, escapedLabel)
+			_, err = fmt.Fprintf(writer, "    \"%s\" [%s, fillcolor=lightblue, %s];\n", nodeID, attrs, mainStyle)
 		} else {
-			_, err = fmt.Fprintf(writer, "    \"%s\" [label=\"%s\"];\n", nodeID, escapedLabel)
+			_, err = fmt.Fprintf(writer, "    \"%s\" [%s];\n", nodeID, attrs)
 		}
 		if err != nil {
 			return err
@@ -174,10 +569,54 @@ func (r *GraphvizRenderer) Render(graph *DependencyGraph, writer io.Writer) erro
 	}
 
 	// Render edges
+	var counts map[string]int
+	if r.CollapseDuplicates {
+		counts = graph.EdgeCounts()
+	}
+	backEdges := make(map[string]bool)
+	if r.RelaxBackEdges {
+		for _, dep := range graph.BackEdges() {
+			backEdges[edgeKey(dep)] = true
+		}
+	}
+	drawn := make(map[string]bool)
 	for _, dep := range graph.Dependencies {
+		label := graph.ViaLabel(dep)
+		if counts != nil {
+			key := edgeKey(dep)
+			if drawn[key] {
+				continue
+			}
+			drawn[key] = true
+			if n := counts[key]; n > 1 {
+				label = strings.TrimSpace(fmt.Sprintf("%s x%d", label, n))
+			}
+		}
+
+		var attrs []string
+		if label != "" {
+			attrs = append(attrs, fmt.Sprintf("label=\"%s\"", r.formatLabel(label)))
+		}
+		if dep.CrossesMajor() {
+			// Highlight edges between major versions of the same module
+			attrs = append(attrs, `color="darkorange"`, "style=dashed")
+		}
+		if dep.IsSelfLoop() {
+			// Highlight edges from a module path to itself
+			attrs = append(attrs, `color="red"`, "style=bold")
+		}
+		if backEdges[edgeKey(dep)] {
+			attrs = append(attrs, "constraint=false")
+		}
+
 		fromID := r.sanitizeNodeID(dep.From.String())
 		toID := r.sanitizeNodeID(dep.To.String())
-		_, err := fmt.Fprintf(writer, "    \"%s\" -> \"%s\";\n", fromID, toID)
+		var err error
+		if len(attrs) > 0 {
+			_, err = fmt.Fprintf(writer, "    \"%s\" -> \"%s\" [%s];\n", fromID, toID, strings.Join(attrs, ", "))
+		} else {
+			_, err = fmt.Fprintf(writer, "    \"%s\" -> \"%s\";\n", fromID, toID)
+		}
 		if err != nil {
 			return err
 		}
@@ -187,6 +626,115 @@ func (r *GraphvizRenderer) Render(graph *DependencyGraph, writer io.Writer) erro
 	return err
 }
 
+// OptionKeys returns the RenderOptions.Options keys the renderer reads
+func (r *GraphvizRenderer) OptionKeys() []string {
+	return []string{"rankdir"}
+}
+
+// rankDir returns the layout direction from the rankdir option, defaulting
+// to LR
+func (r *GraphvizRenderer) rankDir() (string, error) {
+	value, ok := r.Options["rankdir"]
+	if !ok {
+		return "LR", nil
+	}
+	switch rankdir := strings.ToUpper(value); rankdir {
+	case "TB", "BT", "LR", "RL":
+		return rankdir, nil
+	}
+	return "", fmt.Errorf("invalid rankdir option %q, expected TB, BT, LR or RL", value)
+}
+
+// renderPlain writes the body of an attribute-free DOT graph and its closing brace
+func (r *GraphvizRenderer) renderPlain(graph *DependencyGraph, writer io.Writer) error {
+	// Declare modules without edges so they are not lost
+	inDegree, outDegree := graph.DegreeMaps()
+	for _, module := range graph.GetAllModules() {
+		moduleStr := module.String()
+		if inDegree[moduleStr] == 0 && outDegree[moduleStr] == 0 {
+			if _, err := fmt.Fprintf(writer, "    \"%s\";\n", r.sanitizeNodeID(moduleStr)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, dep := range graph.Dependencies {
+		fromID := r.sanitizeNodeID(dep.From.String())
+		toID := r.sanitizeNodeID(dep.To.String())
+		if _, err := fmt.Fprintf(writer, "    \"%s\" -> \"%s\";\n", fromID, toID); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(writer, "}")
+	return err
+}
+
+// formatLabel escapes a label for a quoted DOT string, applying line wrapping if enabled
+func (r *GraphvizRenderer) formatLabel(label string) string {
+	lines := []string{label}
+	if r.WrapLabels > 0 {
+		lines = wrapPath(label, r.WrapLabels)
+	}
+
+	// Escape each line separately so the \n break sequences are not escaped themselves
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, `"`, `\"`)
+	}
+	return strings.Join(lines, `\n`)
+}
+
+// escapeMermaidString replaces quotes with the #quot; entity for a quoted
+// Mermaid label, which has no backslash escapes
+func escapeMermaidString(s string) string {
+	return strings.ReplaceAll(s, `"`, `#quot;`)
+}
+
+// escapeDOTString escapes backslashes and quotes for a quoted DOT attribute value
+func escapeDOTString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+// recordLabel builds a record label with the path and version in separate
+// cells, or a single cell when the module has no version
+func (r *GraphvizRenderer) recordLabel(module Module) string {
+	path := escapeRecordField(r.formatLabel(module.Path))
+	if module.Version == "" {
+		return "{" + path + "}"
+	}
+	return "{" + path + "|" + escapeRecordField(r.formatLabel(module.Version)) + "}"
+}
+
+// escapeRecordField escapes characters that carry structural meaning in DOT record labels
+func escapeRecordField(field string) string {
+	for _, c := range []string{"{", "}", "|", "<", ">"} {
+		field = strings.ReplaceAll(field, c, `\`+c)
+	}
+	return field
+}
+
+// wrapPath splits a path into lines no longer than width where possible,
+// breaking only after "/" separators
+func wrapPath(path string, width int) []string {
+	segments := strings.SplitAfter(path, "/")
+
+	var lines []string
+	current := ""
+	for _, segment := range segments {
+		if current != "" && len(current)+len(segment) > width {
+			lines = append(lines, current)
+			current = ""
+		}
+		current += segment
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
 func (r *GraphvizRenderer) sanitizeNodeID(nodeID string) string {
 	// Replace problematic characters for DOT format
 	sanitized := strings.ReplaceAll(nodeID, "/", "_")
@@ -196,8 +744,661 @@ func (r *GraphvizRenderer) sanitizeNodeID(nodeID string) string {
 	return sanitized
 }
 
+// SummaryRenderer renders a single-line summary of the dependency graph
+type SummaryRenderer struct{}
+
+// NewSummaryRenderer creates a new summary renderer
+func NewSummaryRenderer() *SummaryRenderer {
+	return &SummaryRenderer{}
+}
+
+// Render renders the dependency graph as a one-line summary
+func (r *SummaryRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	stats := graph.Stats()
+	cycles := len(graph.DetectCycles())
+
+	_, err := fmt.Fprintf(writer, "%s: %d modules, %d edges, %d max-depth, %d cycles\n",
+		graph.MainModule.String(), stats.Modules, stats.Edges, stats.MaxDepth, cycles)
+	return err
+}
+
+// ModuleListRenderer renders a flat, sorted list of every module, one per line
+type ModuleListRenderer struct {
+	// NoVersions prints module paths only, listing each path once
+	NoVersions bool
+}
+
+// NewModuleListRenderer creates a new module list renderer
+func NewModuleListRenderer() *ModuleListRenderer {
+	return &ModuleListRenderer{}
+}
+
+// Render renders the unique modules of the dependency graph as a list
+func (r *ModuleListRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	seen := make(map[string]bool)
+	for _, module := range graph.GetAllModules() {
+		line := module.String()
+		if r.NoVersions {
+			line = module.Path
+		}
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+
+		if _, err := fmt.Fprintln(writer, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CSVRenderer renders the dependency graph as CSV, either one row per module
+// with its degree counts or, when Edges is set, one row per dependency edge
+type CSVRenderer struct {
+	Edges bool
+
+	// WithLines adds the source line of each edge to edge output
+	WithLines bool
+}
+
+// NewCSVRenderer creates a new CSV renderer
+func NewCSVRenderer() *CSVRenderer {
+	return &CSVRenderer{}
+}
+
+// Render renders the dependency graph as CSV
+func (r *CSVRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	w := csv.NewWriter(writer)
+
+	if r.Edges {
+		header := []string{"from", "to"}
+		if r.WithLines {
+			header = append(header, "line")
+		}
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		for _, dep := range graph.Dependencies {
+			row := []string{dep.From.String(), dep.To.String()}
+			if r.WithLines {
+				row = append(row, fmt.Sprint(dep.SourceLine))
+			}
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := w.Write([]string{"module", "path", "version", "in_degree", "out_degree"}); err != nil {
+			return err
+		}
+		inDegree, outDegree := graph.DegreeMaps()
+		for _, module := range graph.GetAllModules() {
+			moduleStr := module.String()
+			row := []string{
+				moduleStr,
+				module.Path,
+				module.Version,
+				fmt.Sprint(inDegree[moduleStr]),
+				fmt.Sprint(outDegree[moduleStr]),
+			}
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// JSONRenderer renders the dependency graph as a JSON document
+type JSONRenderer struct {
+	// WithLines adds the source line of each edge to the edge list
+	WithLines bool
+}
+
+// NewJSONRenderer creates a new JSON renderer
+func NewJSONRenderer() *JSONRenderer {
+	return &JSONRenderer{}
+}
+
+type jsonModule struct {
+	Module    string `json:"module"`
+	Path      string `json:"path"`
+	Version   string `json:"version,omitempty"`
+	InDegree  int    `json:"in_degree"`
+	OutDegree int    `json:"out_degree"`
+}
+
+type jsonEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Line int    `json:"line,omitempty"`
+}
+
+type jsonGraph struct {
+	Main    string       `json:"main"`
+	Modules []jsonModule `json:"modules"`
+	Edges   []jsonEdge   `json:"edges"`
+}
+
+// Render renders the dependency graph as JSON with separate module and edge lists
+func (r *JSONRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	doc := jsonGraph{
+		Main:    graph.MainModule.String(),
+		Modules: make([]jsonModule, 0),
+		Edges:   make([]jsonEdge, 0, len(graph.Dependencies)),
+	}
+
+	inDegree, outDegree := graph.DegreeMaps()
+	for _, module := range graph.GetAllModules() {
+		moduleStr := module.String()
+		doc.Modules = append(doc.Modules, jsonModule{
+			Module:    moduleStr,
+			Path:      module.Path,
+			Version:   module.Version,
+			InDegree:  inDegree[moduleStr],
+			OutDegree: outDegree[moduleStr],
+		})
+	}
+	for _, dep := range graph.Dependencies {
+		edge := jsonEdge{From: dep.From.String(), To: dep.To.String()}
+		if r.WithLines {
+			edge.Line = dep.SourceLine
+		}
+		doc.Edges = append(doc.Edges, edge)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// DGMLRenderer renders the dependency graph as Visual Studio DGML
+type DGMLRenderer struct {
+	RenderOptions
+}
+
+// NewDGMLRenderer creates a new DGML renderer
+func NewDGMLRenderer() *DGMLRenderer {
+	return &DGMLRenderer{}
+}
+
+// dgmlMainCategory is the category assigned to the main module's node
+const dgmlMainCategory = "MainModule"
+
+type dgmlNode struct {
+	ID       string `xml:"Id,attr"`
+	Label    string `xml:"Label,attr"`
+	Category string `xml:"Category,attr,omitempty"`
+}
+
+type dgmlLink struct {
+	Source string `xml:"Source,attr"`
+	Target string `xml:"Target,attr"`
+	Label  string `xml:"Label,attr,omitempty"`
+}
+
+type dgmlCategory struct {
+	ID         string `xml:"Id,attr"`
+	Background string `xml:"Background,attr"`
+}
+
+type dgmlGraph struct {
+	XMLName    xml.Name       `xml:"http://schemas.microsoft.com/vs/2009/dgml DirectedGraph"`
+	Nodes      []dgmlNode     `xml:"Nodes>Node"`
+	Links      []dgmlLink     `xml:"Links>Link"`
+	Categories []dgmlCategory `xml:"Categories>Category"`
+}
+
+// Render renders the dependency graph as a DGML DirectedGraph document
+func (r *DGMLRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	return r.wrapOutput(writer, func() error {
+		return r.render(graph, writer)
+	})
+}
+
+func (r *DGMLRenderer) render(graph *DependencyGraph, writer io.Writer) error {
+	labels, err := nodeLabels(graph, r.Labels, r.Aliases)
+	if err != nil {
+		return err
+	}
+
+	doc := dgmlGraph{
+		Categories: []dgmlCategory{{ID: dgmlMainCategory, Background: "LightBlue"}},
+	}
+	mainStr := graph.MainModule.String()
+	for _, module := range graph.GetAllModules() {
+		moduleStr := module.String()
+		node := dgmlNode{ID: moduleStr, Label: labels[moduleStr]}
+		if moduleStr == mainStr {
+			node.Category = dgmlMainCategory
+		}
+		doc.Nodes = append(doc.Nodes, node)
+	}
+	for _, dep := range graph.Dependencies {
+		doc.Links = append(doc.Links, dgmlLink{Source: dep.From.String(), Target: dep.To.String(), Label: graph.ViaLabel(dep)})
+	}
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
+
+// ProtobufRenderer renders the dependency graph as a binary protobuf message
+// following proto/tangled.proto
+type ProtobufRenderer struct{}
+
+// NewProtobufRenderer creates a new protobuf renderer
+func NewProtobufRenderer() *ProtobufRenderer {
+	return &ProtobufRenderer{}
+}
+
+// Render writes the graph encoded with MarshalProto
+func (r *ProtobufRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	data, err := graph.MarshalProto()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// WhyRenderer explains why the main module needs a target module, printing
+// the shortest chain of module paths in the style of "go mod why -m"
+type WhyRenderer struct {
+	// Target is the module to explain, as path@version or path
+	Target string
+}
+
+// NewWhyRenderer creates a new why renderer for target
+func NewWhyRenderer(target string) *WhyRenderer {
+	return &WhyRenderer{Target: target}
+}
+
+// Render writes a "# path" header followed by one module path per line from
+// the main module to the target
+func (r *WhyRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	if r.Target == "" {
+		return fmt.Errorf("why output requires a target module")
+	}
+	target, ok := graph.FindModule(r.Target)
+	if !ok {
+		return fmt.Errorf("module not found in graph: %s", r.Target)
+	}
+
+	if _, err := fmt.Fprintf(writer, "# %s\n", target.Path); err != nil {
+		return err
+	}
+	path := graph.ShortestPath(graph.MainModule, target)
+	if path == nil {
+		_, err := fmt.Fprintf(writer, "(main module does not need module %s)\n", target.Path)
+		return err
+	}
+	for _, module := range path {
+		if _, err := fmt.Fprintln(writer, module.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CypherRenderer renders the dependency graph as Neo4j Cypher statements: a
+// MERGE of a Module node per module followed by a DEPENDS_ON relationship
+// per edge. MERGE keeps the script safe to run more than once.
+type CypherRenderer struct{}
+
+// NewCypherRenderer creates a new Cypher renderer
+func NewCypherRenderer() *CypherRenderer {
+	return &CypherRenderer{}
+}
+
+// Render writes one statement per line, nodes first in sorted module order
+// and then edges in input order
+func (r *CypherRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	for _, module := range graph.GetAllModules() {
+		if _, err := fmt.Fprintf(writer, "MERGE (m:Module %s);\n", cypherModule(module)); err != nil {
+			return err
+		}
+	}
+	for _, dep := range graph.Dependencies {
+		_, err := fmt.Fprintf(writer, "MATCH (a:Module %s), (b:Module %s) MERGE (a)-[:DEPENDS_ON]->(b);\n",
+			cypherModule(dep.From), cypherModule(dep.To))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cypherModule returns the property map identifying a module node
+func cypherModule(module Module) string {
+	return fmt.Sprintf("{path: %s, version: %s}", cypherString(module.Path), cypherString(module.Version))
+}
+
+// cypherString returns s as a single-quoted Cypher string literal
+func cypherString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, `'`, `\'`) + "'"
+}
+
+// HTMLTableRenderer renders the dependency graph as a static HTML page with
+// one table row per edge. Column headers are buttons that sort the table, so
+// the page works with screen readers and without the D3 layout.
+type HTMLTableRenderer struct{}
+
+// NewHTMLTableRenderer creates a new HTML table renderer
+func NewHTMLTableRenderer() *HTMLTableRenderer {
+	return &HTMLTableRenderer{}
+}
+
+// Render writes the page with edges in input order
+func (r *HTMLTableRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	title := html.EscapeString("Dependencies of " + graph.MainModule.String())
+	if _, err := fmt.Fprintf(writer, htmlTableHead, title, title); err != nil {
+		return err
+	}
+
+	for _, dep := range graph.Dependencies {
+		_, err := fmt.Fprintf(writer, "            <tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(dep.From.Path), html.EscapeString(dep.From.Version),
+			html.EscapeString(dep.To.Path), html.EscapeString(dep.To.Version))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(writer, htmlTableFoot)
+	return err
+}
+
+// htmlTableHead opens the HTML table page; both verbs are the escaped title
+const htmlTableHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; margin: 20px; }
+        table { border-collapse: collapse; }
+        th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+        th button { font: inherit; font-weight: bold; background: none; border: none; padding: 0; cursor: pointer; }
+    </style>
+</head>
+<body>
+    <h1>%s</h1>
+    <table id="edges">
+        <thead>
+            <tr>
+                <th scope="col" aria-sort="none"><button type="button">Module</button></th>
+                <th scope="col" aria-sort="none"><button type="button">Version</button></th>
+                <th scope="col" aria-sort="none"><button type="button">Dependency</button></th>
+                <th scope="col" aria-sort="none"><button type="button">Dependency version</button></th>
+            </tr>
+        </thead>
+        <tbody>
+`
+
+// htmlTableFoot closes the HTML table page, including the sorting script
+const htmlTableFoot = `        </tbody>
+    </table>
+    <script>
+        // Sort rows by a column when its header is activated, toggling
+        // between ascending and descending order
+        document.querySelectorAll("#edges th").forEach((th, column) => {
+            th.querySelector("button").addEventListener("click", () => {
+                const ascending = th.getAttribute("aria-sort") !== "ascending";
+                document.querySelectorAll("#edges th").forEach(other => other.setAttribute("aria-sort", "none"));
+                th.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+
+                const tbody = document.querySelector("#edges tbody");
+                const rows = Array.from(tbody.rows);
+                rows.sort((a, b) => {
+                    const order = a.cells[column].textContent.localeCompare(b.cells[column].textContent);
+                    return ascending ? order : -order;
+                });
+                rows.forEach(row => tbody.appendChild(row));
+            });
+        });
+    </script>
+</body>
+</html>
+`
+
+// FoldedRenderer renders the dependency graph in the collapsed stack format
+// read by flame graph tools: one line per path from the main module to a
+// leaf, with modules separated by ";" and followed by a count of 1. A path
+// that would revisit a module on it is truncated there, so cycles end a line.
+type FoldedRenderer struct{}
+
+// NewFoldedRenderer creates a new folded stack renderer
+func NewFoldedRenderer() *FoldedRenderer {
+	return &FoldedRenderer{}
+}
+
+// Render writes the paths depth-first in tree order
+func (r *FoldedRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	tree := graph.GetTree()
+	onPath := make(map[string]bool)
+
+	var walk func(stack []string) error
+	walk = func(stack []string) error {
+		current := stack[len(stack)-1]
+		onPath[current] = true
+		defer delete(onPath, current)
+
+		descended := false
+		for _, child := range tree[current] {
+			if onPath[child] {
+				continue
+			}
+			descended = true
+			if err := walk(append(stack, child)); err != nil {
+				return err
+			}
+		}
+		if descended {
+			return nil
+		}
+		_, err := fmt.Fprintf(writer, "%s 1\n", strings.Join(stack, ";"))
+		return err
+	}
+
+	return walk([]string{graph.MainModule.String()})
+}
+
+// YAMLTreeRenderer renders the dependency graph as a nested YAML tree from
+// the main module down, for tools that expect a hierarchy rather than an edge
+// list. Each node is a mapping with a "module" key and, when it has
+// dependencies, a "dependencies" sequence. A module already expanded
+// elsewhere in the tree appears once more with "ref: true" instead of its
+// dependencies, and an edge back to a module on the current path appears
+// with "cycle: true".
+type YAMLTreeRenderer struct{}
+
+// NewYAMLTreeRenderer creates a new YAML tree renderer
+func NewYAMLTreeRenderer() *YAMLTreeRenderer {
+	return &YAMLTreeRenderer{}
+}
+
+// Render writes the tree depth-first in tree order
+func (r *YAMLTreeRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
+	tree := graph.GetTree()
+	expanded := make(map[string]bool)
+	onPath := make(map[string]bool)
+
+	// walk writes a node whose first line starts with first and whose other
+	// lines start with rest, so sequence items can begin with "- "
+	var walk func(current, first, rest string) error
+	walk = func(current, first, rest string) error {
+		if _, err := fmt.Fprintf(writer, "%smodule: %s\n", first, strconv.Quote(current)); err != nil {
+			return err
+		}
+		if onPath[current] {
+			_, err := fmt.Fprintf(writer, "%scycle: true\n", rest)
+			return err
+		}
+		if expanded[current] {
+			_, err := fmt.Fprintf(writer, "%sref: true\n", rest)
+			return err
+		}
+		expanded[current] = true
+		onPath[current] = true
+		defer delete(onPath, current)
+
+		children := tree[current]
+		if len(children) == 0 {
+			return nil
+		}
+		if _, err := fmt.Fprintf(writer, "%sdependencies:\n", rest); err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err := walk(child, rest+"  - ", rest+"    "); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return walk(graph.MainModule.String(), "", "")
+}
+
 // HTMLRenderer renders the dependency graph as HTML with D3.js
-type HTMLRenderer struct{}
+type HTMLRenderer struct {
+	RenderOptions
+
+	// DisableSearch omits the search box that filters and highlights nodes by name
+	DisableSearch bool
+
+	// PrecomputeLayout positions nodes in Go so the page opens without
+	// running the force simulation
+	PrecomputeLayout bool
+
+	// Width and Height set the SVG canvas size in pixels; zero uses the
+	// defaults of htmlCanvasWidth and htmlCanvasHeight
+	Width  int
+	Height int
+
+	// StableIDs uses a hash of each module string as its node id instead of
+	// its index, so ids survive unrelated modules being added or removed
+	StableIDs bool
+
+	// Centrality selects which edges size each node: incoming (the
+	// default), outgoing, or both
+	Centrality CentralityMode
+
+	// CSSClasses adds organization and depth classes to each node and link
+	// element, such as "node-github-com-mycorp depth-2", for custom styling
+	CSSClasses bool
+
+	// FocusFragment makes the page select and center the module named in a
+	// #focus=<module> URL fragment, so links can point at a node
+	FocusFragment bool
+
+	// Seed, when non-zero, drives the force layout's initial positions and
+	// random jiggle from a seeded generator, so regenerating the page with
+	// the same seed gives the same layout
+	Seed uint32
+}
+
+// htmlCanvasWidth and htmlCanvasHeight are the default SVG canvas size
+const (
+	htmlCanvasWidth  = 1200
+	htmlCanvasHeight = 800
+)
+
+// htmlSearchBox is the markup for the HTML search box
+const htmlSearchBox = `        <div class="search-container">
+            <input type="text" class="search-input" id="search-input" placeholder="Search modules..." autocomplete="off">
+            <span class="search-counter" id="search-counter"></span>
+            <button class="search-clear" id="search-clear">×</button>
+            <div class="search-results" id="search-results"></div>
+        </div>
+`
+
+// htmlFocusScript selects the module named in a #focus=<module> URL fragment,
+// given as a node label, module string or module path, once the layout has
+// settled and whenever the fragment changes
+const htmlFocusScript = `
+        // Focus the module named in the URL fragment
+        function focusFromFragment() {
+            const match = window.location.hash.match(/^#focus=(.+)$/);
+            if (!match) return;
+            const target = decodeURIComponent(match[1]);
+            const focused = nodes.find(n => n.name === target || n.module === target ||
+                (n.module || n.name).startsWith(target + "@"));
+            if (!focused) return;
+
+            selectedNode = focused;
+            updateBreadcrumb(focused);
+            highlightPath(focused);
+            centerOnNode(focused);
+        }
+
+        if (precomputed) {
+            focusFromFragment();
+        } else {
+            simulation.on("end.focus", () => {
+                simulation.on("end.focus", null);
+                focusFromFragment();
+            });
+        }
+        window.addEventListener("hashchange", focusFromFragment);
+`
+
+// htmlSeedScript replaces the force simulation's random source with a
+// mulberry32 generator seeded by its verb and, unless the layout was
+// precomputed, scatters the nodes from it before the simulation runs
+const htmlSeedScript = `
+        // Seeded layout, so every regeneration starts and settles the same way
+        let layoutSeed = %d;
+        function seededRandom() {
+            layoutSeed = (layoutSeed + 0x6D2B79F5) >>> 0;
+            let t = layoutSeed;
+            t = Math.imul(t ^ (t >>> 15), t | 1);
+            t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+            return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+        }
+        simulation.randomSource(seededRandom);
+        if (!precomputed) {
+            nodes.forEach(d => {
+                d.x = seededRandom() * width;
+                d.y = seededRandom() * height;
+            });
+        }
+`
+
+// htmlDefaultCharge is the force layout's node repulsion strength when the
+// charge option is not set
+const htmlDefaultCharge = -300
+
+// OptionKeys returns the RenderOptions.Options keys the renderer reads
+func (r *HTMLRenderer) OptionKeys() []string {
+	return []string{"charge"}
+}
+
+// charge returns the node repulsion strength from the charge option, where
+// more negative values push nodes further apart
+func (r *HTMLRenderer) charge() (float64, error) {
+	value, ok := r.Options["charge"]
+	if !ok {
+		return htmlDefaultCharge, nil
+	}
+	charge, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(charge) || math.IsInf(charge, 0) {
+		return 0, fmt.Errorf("invalid charge option %q, expected a number", value)
+	}
+	return charge, nil
+}
 
 // NewHTMLRenderer creates a new HTML renderer
 func NewHTMLRenderer() *HTMLRenderer {
@@ -213,8 +1414,16 @@ func (r *HTMLRenderer) Render(graph *DependencyGraph, writer io.Writer) error {
 func (r *HTMLRenderer) RenderWithFilename(graph *DependencyGraph, writer io.Writer, filename string) error {
 	template := r.getHTMLTemplate()
 
+	charge, err := r.charge()
+	if err != nil {
+		return err
+	}
+
 	// Generate nodes and links for D3
-	nodes := r.generateNodes(graph)
+	nodes, err := r.generateNodes(graph)
+	if err != nil {
+		return err
+	}
 	links := r.generateLinks(graph)
 
 	// Replace placeholders in template
@@ -222,18 +1431,91 @@ func (r *HTMLRenderer) RenderWithFilename(graph *DependencyGraph, writer io.Writ
 	html = strings.ReplaceAll(html, "{{NODES}}", nodes)
 	html = strings.ReplaceAll(html, "{{LINKS}}", links)
 
-	_, err := writer.Write([]byte(html))
-	return err
+	width, height := r.canvasSize()
+	html = strings.ReplaceAll(html, "{{WIDTH}}", strconv.Itoa(width))
+	html = strings.ReplaceAll(html, "{{HEIGHT}}", strconv.Itoa(height))
+	html = strings.ReplaceAll(html, "{{CHARGE}}", strconv.FormatFloat(charge, 'g', -1, 64))
+	html = strings.ReplaceAll(html, "{{PRECOMPUTED}}", strconv.FormatBool(r.PrecomputeLayout))
+
+	searchBox := htmlSearchBox
+	if r.DisableSearch {
+		searchBox = ""
+	}
+	html = strings.ReplaceAll(html, "{{SEARCH_BOX}}", searchBox)
+
+	focusScript := ""
+	if r.FocusFragment {
+		focusScript = htmlFocusScript
+	}
+	html = strings.ReplaceAll(html, "{{FOCUS_SCRIPT}}", focusScript)
+
+	seedScript := ""
+	if r.Seed != 0 {
+		seedScript = fmt.Sprintf(htmlSeedScript, r.Seed)
+	}
+	html = strings.ReplaceAll(html, "{{SEED_SCRIPT}}", seedScript)
+
+	return r.wrapOutput(writer, func() error {
+		_, err := io.WriteString(writer, html)
+		return err
+	})
+}
+
+// canvasSize returns the SVG canvas size, falling back to the defaults
+func (r *HTMLRenderer) canvasSize() (int, int) {
+	width, height := r.Width, r.Height
+	if width <= 0 {
+		width = htmlCanvasWidth
+	}
+	if height <= 0 {
+		height = htmlCanvasHeight
+	}
+	return width, height
+}
+
+// nodeIDs returns the JSON-encoded id of every module, keyed by module string
+func (r *HTMLRenderer) nodeIDs(modules []Module) map[string]string {
+	ids := make(map[string]string, len(modules))
+	for i, module := range modules {
+		moduleStr := module.String()
+		if r.StableIDs {
+			h := fnv.New64a()
+			_, _ = h.Write([]byte(moduleStr))
+			ids[moduleStr] = fmt.Sprintf(`"%016x"`, h.Sum64())
+		} else {
+			ids[moduleStr] = strconv.Itoa(i)
+		}
+	}
+	return ids
 }
 
-func (r *HTMLRenderer) generateNodes(graph *DependencyGraph) string {
+func (r *HTMLRenderer) generateNodes(graph *DependencyGraph) (string, error) {
+	labels, err := nodeLabels(graph, r.Labels, r.Aliases)
+	if err != nil {
+		return "", err
+	}
+
+	var positions map[string]Point
+	if r.PrecomputeLayout {
+		width, height := r.canvasSize()
+		positions = graph.LayeredLayout(float64(width), float64(height))
+	}
+
 	var nodes []string
 	modules := graph.GetAllModules()
+	ids := r.nodeIDs(modules)
+	degrees := graph.DegreeCentrality(r.Centrality)
+	var depths map[string]int
+	if r.CSSClasses {
+		depths = graph.DepthMap()
+	}
 
-	for i, module := range modules {
+	for _, module := range modules {
 		moduleStr := module.String()
-		escapedLabel := strings.ReplaceAll(moduleStr, `"`, `\"`)
-		escapedLabel = strings.ReplaceAll(escapedLabel, `\`, `\\`)
+		name, err := json.Marshal(labels[moduleStr])
+		if err != nil {
+			return "", err
+		}
 
 		// Mark main module differently
 		group := 1
@@ -241,29 +1523,75 @@ func (r *HTMLRenderer) generateNodes(graph *DependencyGraph) string {
 			group = 2
 		}
 
-		node := fmt.Sprintf(`{"id": %d, "name": "%s", "group": %d}`, i, escapedLabel, group)
-		nodes = append(nodes, node)
+		node := fmt.Sprintf(`{"id": %s, "name": %s, "group": %d, "r": %.1f`, ids[moduleStr], name, group, nodeRadius(degrees[moduleStr]))
+		if p, ok := positions[moduleStr]; ok {
+			node += fmt.Sprintf(`, "x": %.1f, "y": %.1f`, p.X, p.Y)
+		}
+		if _, ok := r.Aliases[module.Path]; ok {
+			original, err := json.Marshal(moduleStr)
+			if err != nil {
+				return "", err
+			}
+			node += fmt.Sprintf(`, "module": %s`, original)
+		}
+		if r.Links {
+			url, err := json.Marshal(moduleURL(module))
+			if err != nil {
+				return "", err
+			}
+			node += fmt.Sprintf(`, "url": %s`, url)
+		}
+		if module.Vulns != "" {
+			vulns, err := json.Marshal(module.Vulns)
+			if err != nil {
+				return "", err
+			}
+			node += fmt.Sprintf(`, "vulns": %s`, vulns)
+		}
+		if r.CSSClasses {
+			classes := "node-" + cssOrg(module.Path)
+			if depth, ok := depths[moduleStr]; ok {
+				classes += fmt.Sprintf(" depth-%d", depth)
+			}
+			node += fmt.Sprintf(`, "classes": "%s"`, classes)
+		}
+		nodes = append(nodes, node+"}")
 	}
 
-	return "[" + strings.Join(nodes, ",\n        ") + "]"
+	return "[" + strings.Join(nodes, ",\n        ") + "]", nil
+}
+
+// nodeRadius returns the HTML circle radius for a node with the given degree,
+// growing with the square root so heavily shared modules stay readable
+func nodeRadius(degree int) float64 {
+	return 6 + 2*math.Sqrt(float64(degree))
+}
+
+// cssOrg returns the host and first path element of a module path as a CSS
+// class fragment, lowercased with other characters replaced by "-", so
+// github.com/MyCorp/api becomes github-com-mycorp
+func cssOrg(path string) string {
+	parts := strings.SplitN(path, "/", 3)
+	org := strings.ToLower(strings.Join(parts[:min(2, len(parts))], "/"))
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, org)
 }
 
 func (r *HTMLRenderer) generateLinks(graph *DependencyGraph) string {
 	var links []string
-	modules := graph.GetAllModules()
-
-	// Create module to index mapping
-	moduleToIndex := make(map[string]int)
-	for i, module := range modules {
-		moduleToIndex[module.String()] = i
-	}
+	ids := r.nodeIDs(graph.GetAllModules())
 
+	weights := graph.EdgeWeights()
 	for _, dep := range graph.Dependencies {
-		fromIndex := moduleToIndex[dep.From.String()]
-		toIndex := moduleToIndex[dep.To.String()]
-
-		link := fmt.Sprintf(`{"source": %d, "target": %d}`, fromIndex, toIndex)
-		links = append(links, link)
+		link := fmt.Sprintf(`{"source": %s, "target": %s, "weight": %d`, ids[dep.From.String()], ids[dep.To.String()], weights[edgeKey(dep)])
+		if r.CSSClasses {
+			link += fmt.Sprintf(`, "classes": "link-from-%s link-to-%s"`, cssOrg(dep.From.Path), cssOrg(dep.To.Path))
+		}
+		links = append(links, link+"}")
 	}
 
 	return "[" + strings.Join(links, ",\n        ") + "]"
@@ -570,13 +1898,7 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
 <body>
     <h1>{{TITLE}}</h1>
     <div id="graph-container">
-        <div class="search-container">
-            <input type="text" class="search-input" id="search-input" placeholder="Search modules..." autocomplete="off">
-            <span class="search-counter" id="search-counter"></span>
-            <button class="search-clear" id="search-clear">×</button>
-            <div class="search-results" id="search-results"></div>
-        </div>
-        <div class="breadcrumb-container" id="breadcrumb-container">
+{{SEARCH_BOX}}        <div class="breadcrumb-container" id="breadcrumb-container">
             <div class="breadcrumb" id="breadcrumb">
                 <span class="breadcrumb-empty">Click a node to see its dependency path</span>
             </div>
@@ -593,11 +1915,12 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
     <div id="tooltip"></div>
 
     <script>
-        const width = 1200;
-        const height = 800;
+        const width = {{WIDTH}};
+        const height = {{HEIGHT}};
 
         const nodes = {{NODES}};
         const links = {{LINKS}};
+        const precomputed = {{PRECOMPUTED}};
 
         const svg = d3.select("#graph")
             .append("svg")
@@ -632,22 +1955,31 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
 
         const simulation = d3.forceSimulation(nodes)
             .force("link", d3.forceLink(links).id(d => d.id).distance(100))
-            .force("charge", d3.forceManyBody().strength(-300))
+            .force("charge", d3.forceManyBody().strength({{CHARGE}}))
             .force("center", d3.forceCenter(width / 2, height / 2));
-
+{{SEED_SCRIPT}}
         const link = g.append("g")
             .selectAll("line")
             .data(links)
             .join("line")
-            .attr("class", "link");
+            .attr("class", d => d.classes ? "link " + d.classes : "link")
+            .attr("stroke-width", d => 1.5 * Math.sqrt(d.weight || 1));
+
+        // Vulnerable modules are filled dark red, the main module light red
+        function nodeFill(d) {
+            if (d.vulns) {
+                return "#c0392b";
+            }
+            return d.group === 2 ? "#ff6b6b" : "#4ecdc4";
+        }
 
         const node = g.append("g")
             .selectAll("circle")
             .data(nodes)
             .join("circle")
-            .attr("class", "node")
-            .attr("r", 8)
-            .attr("fill", d => d.group === 2 ? "#ff6b6b" : "#4ecdc4")
+            .attr("class", d => d.classes ? "node " + d.classes : "node")
+            .attr("r", d => d.r)
+            .attr("fill", nodeFill)
             .call(d3.drag()
                 .on("start", dragstarted)
                 .on("drag", dragged)
@@ -659,7 +1991,7 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
             tooltip.style("opacity", 1)
                 .style("left", (event.pageX + 10) + "px")
                 .style("top", (event.pageY - 10) + "px")
-                .text(d.name);
+                .text((d.module ? d.name + " (" + d.module + ")" : d.name) + (d.vulns ? " - vulnerable: " + d.vulns : ""));
         })
         .on("mouseout", function() {
             tooltip.style("opacity", 0);
@@ -669,6 +2001,11 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
             selectedNode = d;
             updateBreadcrumb(d);
             highlightPath(d);
+        })
+        .on("dblclick", function(event, d) {
+            if (d.url) {
+                window.open(d.url, "_blank", "noopener");
+            }
         });
 
         simulation.on("tick", () => {
@@ -851,6 +2188,13 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
             updateMinimap();
         });
 
+        // Precomputed layouts arrive settled, so draw them once instead of
+        // running the simulation until the user drags a node
+        if (precomputed) {
+            simulation.stop();
+            simulation.on("tick")();
+        }
+
         // Update viewport indicator on zoom
         zoom.on("zoom", function(event) {
             g.attr("transform", event.transform);
@@ -1046,8 +2390,8 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
         d3.select("#breadcrumb").on("click", function(event) {
             const target = event.target;
             if (target.classList.contains("breadcrumb-item") && !target.classList.contains("current")) {
-                const nodeId = parseInt(target.getAttribute("data-node-id"));
-                const node = nodes.find(n => n.id === nodeId);
+                const nodeId = target.getAttribute("data-node-id");
+                const node = nodes.find(n => String(n.id) === nodeId);
                 if (node) {
                     selectedNode = node;
                     updateBreadcrumb(node);
@@ -1165,26 +2509,37 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
             highlightedResultIndex = -1;
         }
 
-        // Highlight matching nodes in the graph
+        // Highlight matching nodes in the graph and dim everything else.
+        // Dimming uses the opacity style so it does not disturb the stroke
+        // attributes used by breadcrumb path highlighting.
         function highlightSearchMatches(matches) {
             if (matches.length === 0) {
                 // Reset all node highlighting
-                node.attr("fill", d => d.group === 2 ? "#ff6b6b" : "#4ecdc4")
-                    .attr("r", 8)
+                node.attr("fill", nodeFill)
+                    .attr("r", d => d.r)
                     .attr("stroke", "#fff")
-                    .attr("stroke-width", 1.5);
+                    .attr("stroke-width", 1.5)
+                    .style("opacity", null);
+                link.style("opacity", null);
                 return;
             }
             
             const matchIds = new Set(matches.map(n => n.id));
             
+            node.style("opacity", d => matchIds.has(d.id) ? 1 : 0.3);
+            link.style("opacity", d => {
+                const sourceId = d.source.id ?? d.source;
+                const targetId = d.target.id ?? d.target;
+                return matchIds.has(sourceId) || matchIds.has(targetId) ? 1 : 0.1;
+            });
+            
             node.attr("fill", d => {
                 if (matchIds.has(d.id)) {
                     return d.group === 2 ? "#ff0000" : "#00cc00";
                 }
                 return d.group === 2 ? "#ff6b6b" : "#cccccc";
             })
-            .attr("r", d => matchIds.has(d.id) ? 10 : 6)
+            .attr("r", d => matchIds.has(d.id) ? d.r + 2 : d.r - 2)
             .attr("stroke", d => matchIds.has(d.id) ? "#333" : "#fff")
             .attr("stroke-width", d => matchIds.has(d.id) ? 2 : 1);
         }
@@ -1245,8 +2600,8 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
         searchResults.on("click", function(event) {
             const item = event.target.closest(".search-result-item");
             if (item) {
-                const nodeId = parseInt(item.getAttribute("data-node-id"));
-                const node = nodes.find(n => n.id === nodeId);
+                const nodeId = item.getAttribute("data-node-id");
+                const node = nodes.find(n => String(n.id) === nodeId);
                 if (node) {
                     centerOnNode(node);
                     selectedNode = node;
@@ -1292,8 +2647,8 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
                 case "Enter":
                     event.preventDefault();
                     if (highlightedResultIndex >= 0 && highlightedResultIndex < resultItems.length) {
-                        const nodeId = parseInt(resultItems[highlightedResultIndex].getAttribute("data-node-id"));
-                        const node = nodes.find(n => n.id === nodeId);
+                        const nodeId = resultItems[highlightedResultIndex].getAttribute("data-node-id");
+                        const node = nodes.find(n => String(n.id) === nodeId);
                         if (node) {
                             centerOnNode(node);
                             selectedNode = node;
@@ -1362,7 +2717,7 @@ func (r *HTMLRenderer) getHTMLTemplate() string {
                     break;
             }
         });
-    </script>
+{{FOCUS_SCRIPT}}    </script>
 </body>
 </html>`
 }
@@ -2,8 +2,18 @@ package tangled
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 func createTestGraph() *DependencyGraph {
@@ -14,102 +24,1109 @@ func createTestGraph() *DependencyGraph {
 	dep2 := Module{Path: "github.com/dep2", Version: "v2.0.0"}
 	subdep := Module{Path: "github.com/subdep", Version: "v1.0.0"}
 
-	graph.AddDependency(mainModule, dep1)
-	graph.AddDependency(mainModule, dep2)
-	graph.AddDependency(dep1, subdep)
+	graph.AddDependency(mainModule, dep1)
+	graph.AddDependency(mainModule, dep2)
+	graph.AddDependency(dep1, subdep)
+
+	return graph
+}
+
+func TestPlaintextRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewPlaintextRenderer()
+
+	var buf bytes.Buffer
+	err := renderer.Render(graph, &buf)
+	if err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+
+	// Check that main module is at the root
+	if !strings.Contains(output, "github.com/example/main") {
+		t.Error("Output should contain main module")
+	}
+
+	// Check that dependencies are included
+	if !strings.Contains(output, "github.com/dep1@v1.0.0") {
+		t.Error("Output should contain dep1")
+	}
+
+	if !strings.Contains(output, "github.com/dep2@v2.0.0") {
+		t.Error("Output should contain dep2")
+	}
+
+	// Check tree structure characters
+	if !strings.Contains(output, "├──") || !strings.Contains(output, "└──") {
+		t.Error("Output should contain tree structure characters")
+	}
+}
+
+func TestPlaintextRenderer_RenderReverse(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	dep1 := Module{Path: "github.com/dep1", Version: "v1.0.0"}
+	dep2 := Module{Path: "github.com/dep2", Version: "v2.0.0"}
+	shared := Module{Path: "github.com/shared", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, dep1)
+	graph.AddDependency(mainModule, dep2)
+	graph.AddDependency(dep1, shared)
+	graph.AddDependency(dep2, shared)
+
+	var buf bytes.Buffer
+	err := NewPlaintextRenderer().RenderReverse(graph, shared, &buf)
+	if err != nil {
+		t.Fatalf("PlaintextRenderer.RenderReverse() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.HasPrefix(output, "github.com/shared@v1.0.0\n") {
+		t.Errorf("Reverse tree should be rooted at the target, got:\n%s", output)
+	}
+	for _, dependent := range []Module{dep1, dep2, mainModule} {
+		if !strings.Contains(output, dependent.String()) {
+			t.Errorf("Reverse tree should contain dependent %s", dependent)
+		}
+	}
+}
+
+func TestPlaintextRenderer_RenderReverseCycle(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, a)
+
+	var buf bytes.Buffer
+	if err := NewPlaintextRenderer().RenderReverse(graph, b, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.RenderReverse() error = %v", err)
+	}
+
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Errorf("Reverse tree with a cycle should stop at the revisited node, got %d lines:\n%s", lines, buf.String())
+	}
+}
+
+func TestPlaintextRenderer_ASCII(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewPlaintextRenderer()
+	renderer.Charset = ASCIICharset
+	renderer.FullPaths = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	for i := 0; i < len(output); i++ {
+		if output[i] > 127 {
+			t.Fatalf("ASCII output contains non-ASCII byte at %d:\n%s", i, output)
+		}
+	}
+
+	expected := `github.com/example/main
+  +-- github.com/example/main > github.com/dep1@v1.0.0
+  |   ` + "`" + `-- github.com/example/main > github.com/dep1@v1.0.0 > github.com/subdep@v1.0.0
+  ` + "`" + `-- github.com/example/main > github.com/dep2@v2.0.0
+`
+	if output != expected {
+		t.Errorf("ASCII output = \n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestPlaintextRenderer_Indent(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewPlaintextRenderer()
+	renderer.Indent = 2
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+
+	expected := `github.com/example/main
+  ├ github.com/dep1@v1.0.0
+  │ └ github.com/subdep@v1.0.0
+  └ github.com/dep2@v2.0.0
+`
+	if buf.String() != expected {
+		t.Errorf("indent 2 output = \n%s\nwant\n%s", buf.String(), expected)
+	}
+}
+
+func TestPlaintextRenderer_Summarize(t *testing.T) {
+	graph := createTestGraph()
+	for _, name := range []string{"a", "b", "c"} {
+		graph.AddDependency(graph.MainModule, Module{Path: "github.com/" + name, Version: "v1.0.0"})
+	}
+	renderer := NewPlaintextRenderer()
+	renderer.Summarize = 2
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+
+	expected := `github.com/example/main
+  ├── github.com/a@v1.0.0
+  ├── github.com/b@v1.0.0
+  └── ... and 3 more
+`
+	if buf.String() != expected {
+		t.Errorf("summarized output = \n%s\nwant\n%s", buf.String(), expected)
+	}
+}
+
+func TestMermaidRenderer_DuplicateLabels(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(graph.MainModule, Module{Path: "github.com/dep1", Version: "v1.1.0"})
+	labels, err := NewLabelTemplate("{{.Path}}")
+	if err != nil {
+		t.Fatalf("NewLabelTemplate() error = %v", err)
+	}
+	renderer := NewMermaidRenderer()
+	renderer.Labels = labels
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("MermaidRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `["github.com/dep1"]`) || !strings.Contains(output, `["github.com/dep1 (2)"]`) {
+		t.Errorf("both versions of dep1 should get distinct labels:\n%s", output)
+	}
+}
+
+func TestRenderOptions_Header(t *testing.T) {
+	graph := createTestGraph()
+	opts := RenderOptions{Header: true, Generated: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	wantCounts := "modules: 4, edges: 3, generated: 2024-05-01T12:00:00Z"
+
+	tests := []struct {
+		name     string
+		renderer interface {
+			Renderer
+			Configurable
+		}
+		wantLine string
+	}{
+		{name: "text", renderer: NewPlaintextRenderer(), wantLine: "# " + wantCounts},
+		{name: "dot", renderer: NewGraphvizRenderer(), wantLine: "// " + wantCounts},
+		{name: "mermaid", renderer: NewMermaidRenderer(), wantLine: "%% " + wantCounts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.renderer.SetRenderOptions(opts)
+			var buf bytes.Buffer
+			if err := tt.renderer.Render(graph, &buf); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			// The header comes first, before any format preamble
+			if first, _, _ := strings.Cut(buf.String(), "\n"); first != tt.wantLine {
+				t.Errorf("first line = %q, want %q", first, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestPlaintextRenderer_ChildCounts(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewPlaintextRenderer()
+	renderer.ChildCounts = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+	expected := `github.com/example/main (2)
+  ├── github.com/dep1@v1.0.0 (1)
+  │   └── github.com/subdep@v1.0.0 (0)
+  └── github.com/dep2@v2.0.0 (0)
+`
+	if buf.String() != expected {
+		t.Errorf("counted output = \n%s\nwant\n%s", buf.String(), expected)
+	}
+
+	renderer.HideZeroCounts = true
+	buf.Reset()
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "(0)") {
+		t.Errorf("leaves should not be marked with HideZeroCounts:\n%s", buf.String())
+	}
+}
+
+func TestPlaintextRenderer_ExpandAll(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(Module{Path: "github.com/dep2", Version: "v2.0.0"}, Module{Path: "github.com/subdep", Version: "v1.0.0"})
+	graph.AddDependency(Module{Path: "github.com/subdep", Version: "v1.0.0"}, Module{Path: "github.com/leaf", Version: "v1.0.0"})
+	renderer := NewPlaintextRenderer()
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+	if got := strings.Count(buf.String(), "github.com/leaf"); got != 1 {
+		t.Errorf("default output shows the shared subtree %d times, want 1", got)
+	}
+
+	renderer.ExpandAll = true
+	buf.Reset()
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+	expected := `github.com/example/main
+  ├── github.com/dep1@v1.0.0
+  │   └── github.com/subdep@v1.0.0
+  │       └── github.com/leaf@v1.0.0
+  └── github.com/dep2@v2.0.0
+      └── github.com/subdep@v1.0.0
+          └── github.com/leaf@v1.0.0
+`
+	if buf.String() != expected {
+		t.Errorf("expanded output = \n%s\nwant\n%s", buf.String(), expected)
+	}
+
+	renderer.ExpandDepth = 2
+	buf.Reset()
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "github.com/leaf") {
+		t.Errorf("ExpandDepth 2 should stop below depth 2:\n%s", buf.String())
+	}
+}
+
+func TestFitConnector(t *testing.T) {
+	tests := []struct {
+		glyph string
+		width int
+		want  string
+	}{
+		{glyph: "├── ", width: 4, want: "├── "},
+		{glyph: "├── ", width: 6, want: "├──── "},
+		{glyph: "├── ", width: 2, want: "├ "},
+		{glyph: "│   ", width: 1, want: "│"},
+		{glyph: "+-- ", width: 3, want: "+- "},
+	}
+
+	for _, tt := range tests {
+		if got := fitConnector(tt.glyph, tt.width); got != tt.want {
+			t.Errorf("fitConnector(%q, %d) = %q, want %q", tt.glyph, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestPlaintextRenderer_FullPaths(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewPlaintextRenderer()
+	renderer.FullPaths = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+
+	expected := "└── github.com/example/main → github.com/dep1@v1.0.0 → github.com/subdep@v1.0.0\n"
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Depth-2 node should show its full path, got:\n%s", buf.String())
+	}
+}
+
+func TestPlaintextRenderer_FullPathsCycle(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, a)
+
+	renderer := NewPlaintextRenderer()
+	renderer.FullPaths = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "example.com/a → example.com/b@v1.0.0 → example.com/a (cycle)") {
+		t.Errorf("Revisited node on the current path should be marked as a cycle, got:\n%s", buf.String())
+	}
+}
+
+func TestMermaidRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewMermaidRenderer()
+
+	var buf bytes.Buffer
+	err := renderer.Render(graph, &buf)
+	if err != nil {
+		t.Fatalf("MermaidRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+
+	// Check that it starts with the correct Mermaid syntax
+	if !strings.HasPrefix(output, "graph TD") {
+		t.Error("Output should start with 'graph TD'")
+	}
+
+	// Check that nodes are defined
+	if !strings.Contains(output, `["github.com/example/main"]`) {
+		t.Error("Output should contain main module node")
+	}
+
+	// Check that edges are defined
+	if !strings.Contains(output, "-->") {
+		t.Error("Output should contain arrows")
+	}
+}
+
+func TestMermaidRenderer_PrefixSuffix(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewMermaidRenderer()
+	renderer.Prefix = "```mermaid\n"
+	renderer.Suffix = "```\n"
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("MermaidRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.HasPrefix(output, "```mermaid\ngraph TD\n") {
+		t.Errorf("output should open with the prefix, got:\n%s", output)
+	}
+	if !strings.HasSuffix(output, "\n```\n") {
+		t.Errorf("output should close with the suffix, got:\n%s", output)
+	}
+}
+
+func TestMermaidRenderer_GitHub(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewMermaidRenderer()
+	renderer.GitHub = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("MermaidRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.HasPrefix(output, "```mermaid\ngraph TD\n") {
+		t.Errorf("output should open with the mermaid fence followed by the graph, got:\n%s", output)
+	}
+	if !strings.HasSuffix(output, "\n```\n") {
+		t.Errorf("output should close the fence, got:\n%s", output)
+	}
+}
+
+func TestGraphvizRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewGraphvizRenderer()
+
+	var buf bytes.Buffer
+	err := renderer.Render(graph, &buf)
+	if err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+
+	// Check that it starts and ends correctly
+	if !strings.HasPrefix(output, "digraph dependencies {") {
+		t.Error("Output should start with 'digraph dependencies {'")
+	}
+
+	if !strings.HasSuffix(strings.TrimSpace(output), "}") {
+		t.Error("Output should end with '}'")
+	}
+
+	// Check that main module is highlighted
+	if !strings.Contains(output, "fillcolor=lightblue") {
+		t.Error("Output should highlight main module")
+	}
+
+	// Check that edges are defined
+	if !strings.Contains(output, "->") {
+		t.Error("Output should contain arrows")
+	}
+}
+
+func TestGraphvizRenderer_WrapLabels(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	long := Module{Path: "github.com/some-organization/a-rather-long-module-name/v2", Version: "v2.0.0"}
+
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, long)
+
+	renderer := NewGraphvizRenderer()
+	renderer.WrapLabels = 20
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	expected := `label="github.com/\nsome-organization/\na-rather-long-module-name/\nv2@v2.0.0"`
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Output should contain wrapped label %s, got:\n%s", expected, buf.String())
+	}
+}
+
+func TestGraphvizRenderer_RecordNodes(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewGraphvizRenderer()
+	renderer.RecordNodes = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "node [shape=record];") {
+		t.Error("Output should use record-shaped nodes")
+	}
+	if !strings.Contains(output, `label="{github.com/dep1|v1.0.0}"`) {
+		t.Errorf("Versioned module should have path and version cells, got:\n%s", output)
+	}
+	if !strings.Contains(output, `label="{github.com/example/main}"`) {
+		t.Errorf("Unversioned module should have a single cell, got:\n%s", output)
+	}
+}
+
+func TestGraphvizRenderer_Plain(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewGraphvizRenderer()
+	renderer.Plain = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, attr := range []string{"fillcolor", "shape", "label", "rankdir"} {
+		if strings.Contains(output, attr) {
+			t.Errorf("Plain output should not contain %q, got:\n%s", attr, output)
+		}
+	}
+	if strings.Count(output, "->") != len(graph.Dependencies) {
+		t.Errorf("Plain output should contain one edge per dependency, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"github_com_example_main" -> "github_com_dep1_v1_0_0";`) {
+		t.Errorf("Plain output should use sanitized IDs, got:\n%s", output)
+	}
+}
+
+func TestGraphvizRenderer_CollapseDuplicates(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(graph.MainModule, Module{Path: "github.com/dep1", Version: "v1.0.0"})
+
+	renderer := NewGraphvizRenderer()
+	renderer.CollapseDuplicates = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	edge := `"github_com_example_main" -> "github_com_dep1_v1_0_0"`
+	if strings.Count(output, edge) != 1 {
+		t.Errorf("duplicate edge should be drawn once, got:\n%s", output)
+	}
+	if !strings.Contains(output, edge+` [label="x2"];`) {
+		t.Errorf("duplicate edge should be labeled with its count, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"github_com_example_main" -> "github_com_dep2_v2_0_0";`) {
+		t.Errorf("single edges should stay unlabeled, got:\n%s", output)
+	}
+}
+
+func TestGraphvizRenderer_CrossesMajor(t *testing.T) {
+	mainModule := Module{Path: "github.com/foo"}
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, Module{Path: "github.com/foo/v2", Version: "v2.0.0"})
+	graph.AddDependency(mainModule, Module{Path: "github.com/bar", Version: "v1.0.0"})
+
+	var buf bytes.Buffer
+	if err := NewGraphvizRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"github_com_foo" -> "github_com_foo_v2_v2_0_0" [color="darkorange", style=dashed];`) {
+		t.Errorf("edge between major versions should be highlighted, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"github_com_foo" -> "github_com_bar_v1_0_0";`) {
+		t.Errorf("other edges should stay unstyled, got:\n%s", output)
+	}
+}
+
+func TestGraphvizRenderer_SelfLoops(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, Module{Path: "github.com/foo", Version: "v1.0.0"})
+	graph.AddDependency(Module{Path: "github.com/foo", Version: "v1.0.0"}, Module{Path: "github.com/foo", Version: "v1.1.0"})
+
+	var buf bytes.Buffer
+	if err := NewGraphvizRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"github_com_foo_v1_0_0" -> "github_com_foo_v1_1_0" [color="red", style=bold];`) {
+		t.Errorf("self-loop should be highlighted, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"github_com_example_main" -> "github_com_foo_v1_0_0";`) {
+		t.Errorf("other edges should stay unstyled, got:\n%s", output)
+	}
+}
+
+func TestGraphvizRenderer_RelaxBackEdges(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(Module{Path: "github.com/subdep", Version: "v1.0.0"}, Module{Path: "github.com/dep1", Version: "v1.0.0"})
+	renderer := NewGraphvizRenderer()
+	renderer.RelaxBackEdges = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"github_com_subdep_v1_0_0" -> "github_com_dep1_v1_0_0" [constraint=false];`) {
+		t.Errorf("back-edge should not constrain the ranking, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"github_com_dep1_v1_0_0" -> "github_com_subdep_v1_0_0";`) {
+		t.Errorf("forward edges should stay unstyled, got:\n%s", output)
+	}
+}
+
+func TestGraphvizRenderer_RankdirOption(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewGraphvizRenderer()
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "rankdir=LR;") {
+		t.Errorf("rankdir should default to LR, got:\n%s", buf.String())
+	}
+
+	renderer.Options = map[string]string{"rankdir": "tb"}
+	buf.Reset()
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "rankdir=TB;") {
+		t.Errorf("rankdir option should set the layout direction, got:\n%s", buf.String())
+	}
+
+	renderer.Options = map[string]string{"rankdir": "sideways"}
+	if err := renderer.Render(graph, &bytes.Buffer{}); err == nil {
+		t.Error("GraphvizRenderer.Render() should reject an unknown rankdir")
+	}
+}
+
+func TestGraphvizRenderer_Separation(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewGraphvizRenderer()
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "ranksep") || strings.Contains(buf.String(), "nodesep") {
+		t.Errorf("spacing should be left to Graphviz by default, got:\n%s", buf.String())
+	}
+
+	renderer.RankSep = 1.5
+	renderer.NodeSep = 0.25
+	buf.Reset()
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "    rankdir=LR;\n    ranksep=1.5;\n    nodesep=0.25;\n") {
+		t.Errorf("spacing should follow rankdir in the graph attributes, got:\n%s", buf.String())
+	}
+}
+
+func TestGraphvizRenderer_Links(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewGraphvizRenderer()
+	renderer.Links = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if got, want := strings.Count(output, "URL="), len(graph.GetAllModules()); got != want {
+		t.Errorf("URL attributes = %d, want %d, got:\n%s", got, want, output)
+	}
+	if !strings.Contains(output, `URL="https://pkg.go.dev/github.com/dep1@v1.0.0"`) {
+		t.Errorf("nodes should link to pkg.go.dev, got:\n%s", output)
+	}
+}
+
+func TestGraphvizRenderer_Aliases(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewGraphvizRenderer()
+	renderer.Aliases = map[string]string{"github.com/dep1": "Dependency One"}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"github_com_dep1_v1_0_0" [label="Dependency One@v1.0.0", tooltip="github.com/dep1@v1.0.0"];`) {
+		t.Errorf("aliased node should keep its ID and show its friendly label, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"github_com_example_main" -> "github_com_dep1_v1_0_0";`) {
+		t.Errorf("edges should still use the original IDs, got:\n%s", output)
+	}
+}
+
+func TestMermaidRenderer_Aliases(t *testing.T) {
+	renderer := NewMermaidRenderer()
+	renderer.Aliases = map[string]string{"github.com/dep1": `Dep "One"`}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(createTestGraph(), &buf); err != nil {
+		t.Fatalf("MermaidRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `["Dep #quot;One#quot;@v1.0.0"]`) {
+		t.Errorf("aliased label should escape quotes as #quot;, got:\n%s", buf.String())
+	}
+}
+
+func TestHTMLRenderer_Aliases(t *testing.T) {
+	renderer := NewHTMLRenderer()
+	renderer.Aliases = map[string]string{"github.com/dep1": `Dep "One"`}
+
+	nodes, err := renderer.generateNodes(createTestGraph())
+	if err != nil {
+		t.Fatalf("generateNodes() error = %v", err)
+	}
+	var parsed []struct {
+		Name   string `json:"name"`
+		Module string `json:"module"`
+	}
+	if err := json.Unmarshal([]byte(nodes), &parsed); err != nil {
+		t.Fatalf("nodes should be valid JSON: %v\n%s", err, nodes)
+	}
+	found := false
+	for _, node := range parsed {
+		if node.Module == "github.com/dep1@v1.0.0" {
+			found = node.Name == `Dep "One"@v1.0.0`
+		}
+	}
+	if !found {
+		t.Errorf("aliased node should keep its quoted name, got %s", nodes)
+	}
+}
+
+func TestEscapeDOTString(t *testing.T) {
+	if got, want := escapeDOTString(`a"b\c`), `a\"b\\c`; got != want {
+		t.Errorf("escapeDOTString() = %q, want %q", got, want)
+	}
+}
+
+func TestWrapPath(t *testing.T) {
+	tests := []struct {
+		path  string
+		width int
+		want  []string
+	}{
+		{path: "github.com/foo", width: 40, want: []string{"github.com/foo"}},
+		{path: "github.com/foo/bar", width: 12, want: []string{"github.com/", "foo/bar"}},
+		{path: "averyveryverylongsegment", width: 5, want: []string{"averyveryverylongsegment"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := wrapPath(tt.path, tt.width)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("wrapPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDGMLRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewDGMLRenderer()
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("DGMLRenderer.Render() error = %v", err)
+	}
+
+	var doc dgmlGraph
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output should be valid XML: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "<Links>") {
+		t.Errorf("output should contain a <Links> element, got:\n%s", buf.String())
+	}
+	if len(doc.Links) != len(graph.Dependencies) {
+		t.Errorf("links = %d, want %d", len(doc.Links), len(graph.Dependencies))
+	}
+	if len(doc.Nodes) != len(graph.GetAllModules()) {
+		t.Errorf("nodes = %d, want %d", len(doc.Nodes), len(graph.GetAllModules()))
+	}
+	for _, node := range doc.Nodes {
+		if (node.Category == dgmlMainCategory) != (node.ID == graph.MainModule.String()) {
+			t.Errorf("only the main module should carry the %s category, got %+v", dgmlMainCategory, node)
+		}
+	}
+}
+
+func TestProtobufRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+
+	var buf bytes.Buffer
+	if err := NewProtobufRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("ProtobufRenderer.Render() error = %v", err)
+	}
+
+	var decoded DependencyGraph
+	if err := decoded.UnmarshalProto(buf.Bytes()); err != nil {
+		t.Fatalf("output should decode: %v", err)
+	}
+	if len(decoded.Dependencies) != len(graph.Dependencies) {
+		t.Errorf("decoded %d edges, want %d", len(decoded.Dependencies), len(graph.Dependencies))
+	}
+}
+
+func TestWhyRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+
+	var buf bytes.Buffer
+	if err := NewWhyRenderer("github.com/subdep").Render(graph, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "# github.com/subdep\ngithub.com/example/main\ngithub.com/dep1\ngithub.com/subdep\n"
+	if buf.String() != want {
+		t.Errorf("Render() = %q, want %q", buf.String(), want)
+	}
+
+	graph.AddDependency(Module{Path: "github.com/orphan", Version: "v1.0.0"}, Module{Path: "github.com/lonely", Version: "v1.0.0"})
+	buf.Reset()
+	if err := NewWhyRenderer("github.com/lonely@v1.0.0").Render(graph, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "# github.com/lonely\n(main module does not need module github.com/lonely)\n"; buf.String() != want {
+		t.Errorf("Render() = %q, want %q", buf.String(), want)
+	}
+
+	if err := NewWhyRenderer("").Render(graph, &buf); err == nil {
+		t.Error("Render() should fail without a target")
+	}
+}
+
+func TestCypherRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+
+	var buf bytes.Buffer
+	if err := NewCypherRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("CypherRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if got := strings.Count(output, "MERGE (m:Module {"); got != 4 {
+		t.Errorf("Module merges = %d, want one per module (4)", got)
+	}
+	if got := strings.Count(output, "-[:DEPENDS_ON]->"); got != len(graph.Dependencies) {
+		t.Errorf("DEPENDS_ON lines = %d, want one per edge (%d)", got, len(graph.Dependencies))
+	}
+	want := "MATCH (a:Module {path: 'github.com/dep1', version: 'v1.0.0'}), (b:Module {path: 'github.com/subdep', version: 'v1.0.0'}) MERGE (a)-[:DEPENDS_ON]->(b);"
+	if !strings.Contains(output, want) {
+		t.Errorf("output should contain %q:\n%s", want, output)
+	}
+}
+
+func TestCypherString(t *testing.T) {
+	if got, want := cypherString(`it's a \ path`), `'it\'s a \\ path'`; got != want {
+		t.Errorf("cypherString() = %s, want %s", got, want)
+	}
+}
+
+func TestHTMLRenderer_PrecomputedFlag(t *testing.T) {
+	render := func(t *testing.T, renderer *HTMLRenderer) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := renderer.Render(createTestGraph(), &buf); err != nil {
+			t.Fatalf("HTMLRenderer.Render() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	// d3 gives every node an x when the simulation is created, so the page
+	// must be told whether positions were precomputed
+	output := render(t, NewHTMLRenderer())
+	if !strings.Contains(output, "const precomputed = false;") {
+		t.Error("default HTML should declare the layout as not precomputed")
+	}
+	if strings.Contains(output, "d.x !== undefined") {
+		t.Error("HTML should not infer a precomputed layout from node coordinates")
+	}
+	if !strings.Contains(output, "if (precomputed) {\n            simulation.stop();") {
+		t.Error("the simulation should only be stopped for precomputed layouts")
+	}
+
+	renderer := NewHTMLRenderer()
+	renderer.PrecomputeLayout = true
+	if output := render(t, renderer); !strings.Contains(output, "const precomputed = true;") {
+		t.Error("precomputed HTML should declare the layout as precomputed")
+	}
+}
+
+func TestHTMLRenderer_PrecomputeLayout(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewHTMLRenderer()
+	renderer.PrecomputeLayout = true
+
+	nodes, err := renderer.generateNodes(graph)
+	if err != nil {
+		t.Fatalf("generateNodes() error = %v", err)
+	}
 
-	return graph
+	var parsed []struct {
+		Name string  `json:"name"`
+		X    float64 `json:"x"`
+		Y    float64 `json:"y"`
+	}
+	if err := json.Unmarshal([]byte(nodes), &parsed); err != nil {
+		t.Fatalf("nodes should be valid JSON: %v", err)
+	}
+	for _, node := range parsed {
+		if node.X == 0 || node.Y == 0 {
+			t.Errorf("node %s should carry non-zero coordinates, got (%v, %v)", node.Name, node.X, node.Y)
+		}
+	}
 }
 
-func TestPlaintextRenderer_Render(t *testing.T) {
+func TestHTMLRenderer_CanvasSize(t *testing.T) {
 	graph := createTestGraph()
-	renderer := NewPlaintextRenderer()
+	renderer := NewHTMLRenderer()
 
 	var buf bytes.Buffer
-	err := renderer.Render(graph, &buf)
-	if err != nil {
-		t.Fatalf("PlaintextRenderer.Render() error = %v", err)
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "const width = 1200;") || !strings.Contains(buf.String(), "const height = 800;") {
+		t.Error("HTML should default to a 1200x800 canvas")
 	}
 
-	output := buf.String()
+	renderer.Width = 1920
+	buf.Reset()
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "const width = 1920;") || !strings.Contains(buf.String(), "const height = 800;") {
+		t.Error("HTML should use the custom width and keep the default height")
+	}
+}
 
-	// Check that main module is at the root
-	if !strings.Contains(output, "github.com/example/main") {
-		t.Error("Output should contain main module")
+func TestHTMLRenderer_StableIDs(t *testing.T) {
+	// nodeID returns the id rendered for the node named name
+	nodeID := func(t *testing.T, graph *DependencyGraph, name string) string {
+		t.Helper()
+		renderer := NewHTMLRenderer()
+		renderer.StableIDs = true
+		nodes, err := renderer.generateNodes(graph)
+		if err != nil {
+			t.Fatalf("generateNodes() error = %v", err)
+		}
+
+		var parsed []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal([]byte(nodes), &parsed); err != nil {
+			t.Fatalf("nodes should be valid JSON: %v", err)
+		}
+		for _, node := range parsed {
+			if node.Name == name {
+				return node.ID
+			}
+		}
+		t.Fatalf("node %s not found", name)
+		return ""
 	}
 
-	// Check that dependencies are included
-	if !strings.Contains(output, "github.com/dep1@v1.0.0") {
-		t.Error("Output should contain dep1")
+	graph := createTestGraph()
+	before := nodeID(t, graph, "github.com/subdep@v1.0.0")
+	graph.AddDependency(graph.MainModule, Module{Path: "github.com/aaa", Version: "v1.0.0"})
+	if after := nodeID(t, graph, "github.com/subdep@v1.0.0"); after != before {
+		t.Errorf("id changed from %s to %s after adding an unrelated module", before, after)
 	}
 
-	if !strings.Contains(output, "github.com/dep2@v2.0.0") {
-		t.Error("Output should contain dep2")
+	renderer := NewHTMLRenderer()
+	renderer.StableIDs = true
+	if links := renderer.generateLinks(graph); !strings.Contains(links, `"target": "`+before+`"`) {
+		t.Errorf("links should reference the stable id %s: %s", before, links)
 	}
+}
 
-	// Check tree structure characters
-	if !strings.Contains(output, "├──") || !strings.Contains(output, "└──") {
-		t.Error("Output should contain tree structure characters")
+func TestHTMLRenderer_Centrality(t *testing.T) {
+	// largest returns the name of the node drawn with the biggest radius
+	largest := func(t *testing.T, graph *DependencyGraph, mode CentralityMode) string {
+		t.Helper()
+		renderer := NewHTMLRenderer()
+		renderer.Centrality = mode
+		nodes, err := renderer.generateNodes(graph)
+		if err != nil {
+			t.Fatalf("generateNodes() error = %v", err)
+		}
+
+		var parsed []struct {
+			Name string  `json:"name"`
+			R    float64 `json:"r"`
+		}
+		if err := json.Unmarshal([]byte(nodes), &parsed); err != nil {
+			t.Fatalf("nodes should be valid JSON: %v", err)
+		}
+		name, radius := "", 0.0
+		for _, node := range parsed {
+			if node.R > radius {
+				name, radius = node.Name, node.R
+			}
+		}
+		return name
+	}
+
+	graph := createTestGraph()
+	graph.AddDependency(Module{Path: "github.com/dep2", Version: "v2.0.0"}, Module{Path: "github.com/subdep", Version: "v1.0.0"})
+
+	if got := largest(t, graph, CentralityIn); got != "github.com/subdep@v1.0.0" {
+		t.Errorf("largest node with in = %s, want the most depended-upon module", got)
+	}
+	if got := largest(t, graph, CentralityOut); got != "github.com/example/main" {
+		t.Errorf("largest node with out = %s, want the module with most dependencies", got)
+	}
+
+	if got := largest(t, graph, ""); got != "github.com/subdep@v1.0.0" {
+		t.Errorf("largest node by default = %s, want the most depended-upon module", got)
 	}
 }
 
-func TestMermaidRenderer_Render(t *testing.T) {
+func TestHTMLRenderer_CSSClasses(t *testing.T) {
 	graph := createTestGraph()
-	renderer := NewMermaidRenderer()
+	graph.AddDependency(graph.MainModule, Module{Path: "golang.org/x/Text", Version: "v0.3.0"})
 
+	renderer := NewHTMLRenderer()
+	renderer.CSSClasses = true
 	var buf bytes.Buffer
-	err := renderer.Render(graph, &buf)
-	if err != nil {
-		t.Fatalf("MermaidRenderer.Render() error = %v", err)
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("HTMLRenderer.Render() error = %v", err)
 	}
-
 	output := buf.String()
 
-	// Check that it starts with the correct Mermaid syntax
-	if !strings.HasPrefix(output, "graph TD") {
-		t.Error("Output should start with 'graph TD'")
+	for _, want := range []string{
+		`"classes": "node-github-com-example depth-0"`,
+		`"classes": "node-github-com-subdep depth-2"`,
+		`"classes": "node-golang-org-x depth-1"`,
+		`"classes": "link-from-github-com-example link-to-golang-org-x"`,
+		`.attr("class", d => d.classes ? "node " + d.classes : "node")`,
+		`.attr("class", d => d.classes ? "link " + d.classes : "link")`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("HTML should contain %s", want)
+		}
 	}
 
-	// Check that nodes are defined
-	if !strings.Contains(output, `["github.com/example/main"]`) {
-		t.Error("Output should contain main module node")
+	buf.Reset()
+	if err := NewHTMLRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("HTMLRenderer.Render() error = %v", err)
 	}
-
-	// Check that edges are defined
-	if !strings.Contains(output, "-->") {
-		t.Error("Output should contain arrows")
+	if strings.Contains(buf.String(), `"classes"`) {
+		t.Error("classes should only be emitted when CSSClasses is set")
 	}
 }
 
-func TestGraphvizRenderer_Render(t *testing.T) {
-	graph := createTestGraph()
-	renderer := NewGraphvizRenderer()
+func TestHTMLRenderer_FocusFragment(t *testing.T) {
+	render := func(t *testing.T, focus bool) string {
+		t.Helper()
+		renderer := NewHTMLRenderer()
+		renderer.FocusFragment = focus
+		var buf bytes.Buffer
+		if err := renderer.Render(createTestGraph(), &buf); err != nil {
+			t.Fatalf("HTMLRenderer.Render() error = %v", err)
+		}
+		return buf.String()
+	}
 
-	var buf bytes.Buffer
-	err := renderer.Render(graph, &buf)
-	if err != nil {
-		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	output := render(t, true)
+	for _, want := range []string{
+		`window.location.hash.match(/^#focus=(.+)$/)`,
+		`highlightPath(focused);`,
+		`centerOnNode(focused);`,
+		`window.addEventListener("hashchange", focusFromFragment);`,
+		// Wait for the layout to settle unless positions were precomputed
+		"if (precomputed) {\n            focusFromFragment();",
+		`simulation.on("end.focus", () => {`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("HTML should contain %s when FocusFragment is set", want)
+		}
+	}
+	if strings.Contains(output, "{{FOCUS_SCRIPT}}") {
+		t.Error("focus placeholder should be replaced")
 	}
 
-	output := buf.String()
+	if output := render(t, false); strings.Contains(output, "focusFromFragment") || strings.Contains(output, "{{FOCUS_SCRIPT}}") {
+		t.Error("fragment handling should only be emitted when FocusFragment is set")
+	}
+}
 
-	// Check that it starts and ends correctly
-	if !strings.HasPrefix(output, "digraph dependencies {") {
-		t.Error("Output should start with 'digraph dependencies {'")
+func TestHTMLRenderer_Seed(t *testing.T) {
+	render := func(t *testing.T, renderer *HTMLRenderer) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := renderer.Render(createTestGraph(), &buf); err != nil {
+			t.Fatalf("HTMLRenderer.Render() error = %v", err)
+		}
+		return buf.String()
 	}
 
-	if !strings.HasSuffix(strings.TrimSpace(output), "}") {
-		t.Error("Output should end with '}'")
+	renderer := NewHTMLRenderer()
+	renderer.Seed = 42
+	output := render(t, renderer)
+	for _, want := range []string{"let layoutSeed = 42;", "simulation.randomSource(seededRandom);", "if (!precomputed) {"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("seeded HTML should contain %s", want)
+		}
+	}
+	// Seeded positions are only a starting point, so the layout must still run
+	if !strings.Contains(output, "const precomputed = false;") || !strings.Contains(output, "if (precomputed) {\n            simulation.stop();") {
+		t.Error("seeded HTML without a precomputed layout should let the simulation run")
+	}
+	if again := render(t, renderer); again != output {
+		t.Error("rendering with the same seed should give identical HTML")
 	}
 
-	// Check that main module is highlighted
-	if !strings.Contains(output, "fillcolor=lightblue") {
-		t.Error("Output should highlight main module")
+	renderer.PrecomputeLayout = true
+	if output := render(t, renderer); !strings.Contains(output, "const precomputed = true;") || !strings.Contains(output, "if (!precomputed) {") {
+		t.Error("precomputed positions should not be scattered by the seed")
 	}
 
-	// Check that edges are defined
-	if !strings.Contains(output, "->") {
-		t.Error("Output should contain arrows")
+	if output := render(t, NewHTMLRenderer()); strings.Contains(output, "layoutSeed") || strings.Contains(output, "{{SEED_SCRIPT}}") {
+		t.Error("seeding should only be emitted when Seed is set")
 	}
 }
 
@@ -145,6 +1162,196 @@ func TestHTMLRenderer_Render(t *testing.T) {
 	}
 }
 
+func TestSummaryRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewSummaryRenderer()
+
+	var buf bytes.Buffer
+	err := renderer.Render(graph, &buf)
+	if err != nil {
+		t.Fatalf("SummaryRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	expected := "github.com/example/main: 4 modules, 3 edges, 2 max-depth, 0 cycles\n"
+	if output != expected {
+		t.Errorf("SummaryRenderer.Render() = %q, want %q", output, expected)
+	}
+}
+
+func TestModuleListRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(Module{Path: "github.com/dep2", Version: "v2.0.0"}, Module{Path: "github.com/subdep", Version: "v1.1.0"})
+
+	var buf bytes.Buffer
+	if err := NewModuleListRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("ModuleListRenderer.Render() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(graph.GetAllModules()) {
+		t.Errorf("got %d lines, want %d", len(lines), len(graph.GetAllModules()))
+	}
+	if !sort.StringsAreSorted(lines) {
+		t.Errorf("module list should be sorted, got %v", lines)
+	}
+
+	renderer := NewModuleListRenderer()
+	renderer.NoVersions = true
+	buf.Reset()
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("ModuleListRenderer.Render() error = %v", err)
+	}
+	if buf.String() != "github.com/dep1\ngithub.com/dep2\ngithub.com/example/main\ngithub.com/subdep\n" {
+		t.Errorf("paths-only list should contain each path once, got:\n%s", buf.String())
+	}
+}
+
+func TestCSVRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewCSVRenderer()
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("CSVRenderer.Render() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output should be valid CSV: %v", err)
+	}
+
+	if len(records) != 5 {
+		t.Fatalf("CSV should have a header and 4 module rows, got %d rows", len(records))
+	}
+	if strings.Join(records[0], ",") != "module,path,version,in_degree,out_degree" {
+		t.Errorf("unexpected header %v", records[0])
+	}
+
+	directDeps := len(graph.GetDirectDependencies(graph.MainModule))
+	for _, record := range records[1:] {
+		if record[0] == graph.MainModule.String() && record[4] != fmt.Sprint(directDeps) {
+			t.Errorf("main module out_degree = %s, want %d", record[4], directDeps)
+		}
+	}
+}
+
+func TestCSVRenderer_RenderEdges(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewCSVRenderer()
+	renderer.Edges = true
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("CSVRenderer.Render() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output should be valid CSV: %v", err)
+	}
+	if len(records) != 4 {
+		t.Errorf("CSV should have a header and 3 edge rows, got %d rows", len(records))
+	}
+}
+
+func TestCSVRenderer_WithLines(t *testing.T) {
+	graph, err := ParseGraph(strings.NewReader("github.com/example/main github.com/dep1@v1.0.0\n\ngithub.com/example/main github.com/dep2@v2.0.0"))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+
+	renderer := &CSVRenderer{Edges: true, WithLines: true}
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("CSVRenderer.Render() error = %v", err)
+	}
+
+	expected := "from,to,line\ngithub.com/example/main,github.com/dep1@v1.0.0,1\ngithub.com/example/main,github.com/dep2@v2.0.0,3\n"
+	if buf.String() != expected {
+		t.Errorf("CSV output = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestJSONRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewJSONRenderer()
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("JSONRenderer.Render() error = %v", err)
+	}
+
+	var doc jsonGraph
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output should be valid JSON: %v", err)
+	}
+
+	if len(doc.Modules) != 4 || len(doc.Edges) != 3 {
+		t.Errorf("got %d modules and %d edges, want 4 and 3", len(doc.Modules), len(doc.Edges))
+	}
+
+	directDeps := len(graph.GetDirectDependencies(graph.MainModule))
+	for _, module := range doc.Modules {
+		if module.Module == graph.MainModule.String() && module.OutDegree != directDeps {
+			t.Errorf("main module out_degree = %d, want %d", module.OutDegree, directDeps)
+		}
+	}
+}
+
+func TestHTMLRenderer_Search(t *testing.T) {
+	graph := createTestGraph()
+
+	var enabled bytes.Buffer
+	if err := NewHTMLRenderer().Render(graph, &enabled); err != nil {
+		t.Fatalf("HTMLRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(enabled.String(), `<input type="text" class="search-input" id="search-input"`) {
+		t.Error("Output should contain the search input when search is enabled")
+	}
+	if !strings.Contains(enabled.String(), `link.style("opacity"`) {
+		t.Error("Output should dim non-matching links while searching")
+	}
+	if strings.Contains(enabled.String(), "{{SEARCH_BOX}}") {
+		t.Error("Search placeholder should be replaced")
+	}
+
+	renderer := NewHTMLRenderer()
+	renderer.DisableSearch = true
+	var disabled bytes.Buffer
+	if err := renderer.Render(graph, &disabled); err != nil {
+		t.Fatalf("HTMLRenderer.Render() error = %v", err)
+	}
+	if strings.Contains(disabled.String(), `id="search-input"`) {
+		t.Error("Output should omit the search input when search is disabled")
+	}
+	if !strings.Contains(disabled.String(), `id="breadcrumb"`) || !strings.Contains(disabled.String(), `id="minimap"`) {
+		t.Error("Breadcrumb and minimap should remain when search is disabled")
+	}
+}
+
+func TestJSONRenderer_WithLines(t *testing.T) {
+	graph, err := ParseGraph(strings.NewReader("github.com/example/main github.com/dep1@v1.0.0"))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+
+	renderer := NewJSONRenderer()
+	renderer.WithLines = true
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("JSONRenderer.Render() error = %v", err)
+	}
+
+	var doc jsonGraph
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output should be valid JSON: %v", err)
+	}
+	if doc.Edges[0].Line != 1 {
+		t.Errorf("edge line = %d, want 1", doc.Edges[0].Line)
+	}
+}
+
 func TestGraphvizRenderer_sanitizeNodeID(t *testing.T) {
 	renderer := NewGraphvizRenderer()
 
@@ -180,7 +1387,10 @@ func TestHTMLRenderer_generateNodes(t *testing.T) {
 	graph := createTestGraph()
 	renderer := NewHTMLRenderer()
 
-	nodes := renderer.generateNodes(graph)
+	nodes, err := renderer.generateNodes(graph)
+	if err != nil {
+		t.Fatalf("generateNodes() error = %v", err)
+	}
 
 	// Check that nodes are in JSON format
 	if !strings.HasPrefix(nodes, "[") || !strings.HasSuffix(nodes, "]") {
@@ -198,6 +1408,35 @@ func TestHTMLRenderer_generateNodes(t *testing.T) {
 	}
 }
 
+func TestHTMLRenderer_generateNodesEscapesLabels(t *testing.T) {
+	labels, err := NewLabelTemplate(`{{.Path}} "q" \ </script>`)
+	if err != nil {
+		t.Fatalf("NewLabelTemplate() error = %v", err)
+	}
+	renderer := NewHTMLRenderer()
+	renderer.Labels = labels
+
+	nodes, err := renderer.generateNodes(createTestGraph())
+	if err != nil {
+		t.Fatalf("generateNodes() error = %v", err)
+	}
+	if strings.Contains(nodes, "</script>") {
+		t.Errorf("nodes should not close the script element: %s", nodes)
+	}
+
+	var parsed []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(nodes), &parsed); err != nil {
+		t.Fatalf("nodes should be valid JSON: %v\n%s", err, nodes)
+	}
+	for _, node := range parsed {
+		if !strings.HasSuffix(node.Name, ` "q" \ </script>`) {
+			t.Errorf("name = %q, want the templated label intact", node.Name)
+		}
+	}
+}
+
 func TestHTMLRenderer_generateLinks(t *testing.T) {
 	graph := createTestGraph()
 	renderer := NewHTMLRenderer()
@@ -213,6 +1452,156 @@ func TestHTMLRenderer_generateLinks(t *testing.T) {
 	if !strings.Contains(links, `"source":`) || !strings.Contains(links, `"target":`) {
 		t.Error("Links should contain source and target indices")
 	}
+
+	var parsed []map[string]any
+	if err := json.Unmarshal([]byte(links), &parsed); err != nil {
+		t.Fatalf("Links should be valid JSON: %v", err)
+	}
+	for _, link := range parsed {
+		if weight, ok := link["weight"].(float64); !ok || weight < 1 {
+			t.Errorf("link %v should carry a numeric weight of at least 1", link)
+		}
+	}
+}
+
+func TestHTMLTableRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	graph.AddDependency(Module{Path: "github.com/dep2", Version: "v2.0.0"}, Module{Path: "github.com/<script>", Version: "v1.0.0"})
+
+	var buf bytes.Buffer
+	if err := NewHTMLTableRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("HTMLTableRenderer.Render() error = %v", err)
+	}
+
+	output := buf.String()
+	if strings.Count(output, "<table") != 1 {
+		t.Errorf("output should contain one table:\n%s", output)
+	}
+	if rows := strings.Count(output, "<tr><td>"); rows != len(graph.Dependencies) {
+		t.Errorf("table has %d body rows, want one per edge (%d)", rows, len(graph.Dependencies))
+	}
+	if !strings.Contains(output, "<tr><td>github.com/dep1</td><td>v1.0.0</td><td>github.com/subdep</td><td>v1.0.0</td></tr>") {
+		t.Errorf("edge row should list both modules and versions:\n%s", output)
+	}
+	if strings.Contains(output, "github.com/<script>") || !strings.Contains(output, "github.com/&lt;script&gt;") {
+		t.Error("module paths should be HTML-escaped")
+	}
+	if strings.Contains(output, "d3") {
+		t.Error("the table page should not load D3")
+	}
+}
+
+func TestFoldedRenderer_Render(t *testing.T) {
+	graph := createTestGraph()
+	// Close a cycle back to dep1, which should truncate the path at subdep
+	graph.AddDependency(Module{Path: "github.com/subdep", Version: "v1.0.0"}, Module{Path: "github.com/dep1", Version: "v1.0.0"})
+
+	var buf bytes.Buffer
+	if err := NewFoldedRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("FoldedRenderer.Render() error = %v", err)
+	}
+
+	want := "github.com/example/main;github.com/dep1@v1.0.0;github.com/subdep@v1.0.0 1\n" +
+		"github.com/example/main;github.com/dep2@v2.0.0 1\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		stack, count, ok := strings.Cut(line, " ")
+		if !ok || count != "1" || !strings.Contains(stack, ";") {
+			t.Errorf("line %q should be a ;-separated stack followed by a count", line)
+		}
+	}
+}
+
+func TestOptionKeys(t *testing.T) {
+	tests := map[string]Renderer{
+		"rankdir": NewGraphvizRenderer(),
+		"charge":  NewHTMLRenderer(),
+	}
+	for key, renderer := range tests {
+		keyer, ok := renderer.(OptionKeyer)
+		if !ok || !slices.Contains(keyer.OptionKeys(), key) {
+			t.Errorf("%T should list the %s option", renderer, key)
+		}
+	}
+	if _, ok := Renderer(NewPlaintextRenderer()).(OptionKeyer); ok {
+		t.Error("PlaintextRenderer reads no options and should not implement OptionKeyer")
+	}
+}
+
+func TestHTMLRenderer_ChargeOption(t *testing.T) {
+	graph := createTestGraph()
+	renderer := NewHTMLRenderer()
+
+	var buf bytes.Buffer
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("HTMLRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "d3.forceManyBody().strength(-300)") {
+		t.Error("charge should default to -300")
+	}
+
+	renderer.Options = map[string]string{"charge": "-500", "rankdir": "TB"}
+	buf.Reset()
+	if err := renderer.Render(graph, &buf); err != nil {
+		t.Fatalf("HTMLRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "d3.forceManyBody().strength(-500)") {
+		t.Error("charge option should set the repulsion strength")
+	}
+
+	renderer.Options = map[string]string{"charge": "strong"}
+	if err := renderer.Render(graph, &bytes.Buffer{}); err == nil {
+		t.Error("HTMLRenderer.Render() should reject a non-numeric charge")
+	}
+}
+
+func TestYAMLTreeRenderer_Render(t *testing.T) {
+	// A diamond main -> a, b -> shared, with shared closing a cycle back to a
+	mainModule := Module{Path: "github.com/example/main"}
+	a := Module{Path: "github.com/a", Version: "v1.0.0"}
+	b := Module{Path: "github.com/b", Version: "v1.0.0"}
+	shared := Module{Path: "github.com/shared", Version: "v1.0.0"}
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, a)
+	graph.AddDependency(mainModule, b)
+	graph.AddDependency(a, shared)
+	graph.AddDependency(b, shared)
+	graph.AddDependency(shared, a)
+
+	var buf bytes.Buffer
+	if err := NewYAMLTreeRenderer().Render(graph, &buf); err != nil {
+		t.Fatalf("YAMLTreeRenderer.Render() error = %v", err)
+	}
+
+	type node struct {
+		Module       string `yaml:"module"`
+		Ref          bool   `yaml:"ref"`
+		Cycle        bool   `yaml:"cycle"`
+		Dependencies []node `yaml:"dependencies"`
+	}
+	var root node
+	if err := yaml.Unmarshal(buf.Bytes(), &root); err != nil {
+		t.Fatalf("output should parse as YAML: %v\n%s", err, buf.String())
+	}
+
+	want := node{Module: "github.com/example/main", Dependencies: []node{
+		{Module: "github.com/a@v1.0.0", Dependencies: []node{
+			{Module: "github.com/shared@v1.0.0", Dependencies: []node{
+				{Module: "github.com/a@v1.0.0", Cycle: true},
+			}},
+		}},
+		{Module: "github.com/b@v1.0.0", Dependencies: []node{
+			{Module: "github.com/shared@v1.0.0", Ref: true},
+		}},
+	}}
+	if !reflect.DeepEqual(root, want) {
+		t.Errorf("parsed tree = %+v, want %+v\n%s", root, want, buf.String())
+	}
+	if n := strings.Count(buf.String(), "dependencies:"); n != 4 {
+		t.Errorf("shared module should be expanded once, got %d dependency lists:\n%s", n, buf.String())
+	}
 }
 
 func TestRendererInterfaces(t *testing.T) {
@@ -221,4 +1610,15 @@ func TestRendererInterfaces(t *testing.T) {
 	var _ Renderer = &MermaidRenderer{}
 	var _ Renderer = &GraphvizRenderer{}
 	var _ Renderer = &HTMLRenderer{}
+	var _ Renderer = &SummaryRenderer{}
+	var _ Renderer = &CSVRenderer{}
+	var _ Renderer = &JSONRenderer{}
+	var _ Renderer = &ModuleListRenderer{}
+	var _ Renderer = &DGMLRenderer{}
+	var _ Renderer = &ProtobufRenderer{}
+	var _ Renderer = &WhyRenderer{}
+	var _ Renderer = &CypherRenderer{}
+	var _ Renderer = &FoldedRenderer{}
+	var _ Renderer = &HTMLTableRenderer{}
+	var _ Renderer = &YAMLTreeRenderer{}
 }
@@ -0,0 +1,69 @@
+package tangled
+
+// Scope records whether a module is needed by production code, only by
+// tests, or by both
+type Scope string
+
+// Module scopes set by ApplyTestScope
+const (
+	ScopeProd Scope = "prod"
+	ScopeTest Scope = "test"
+	ScopeBoth Scope = "both"
+)
+
+// ApplyTestScope sets the Scope of every module from the main module's
+// test-only imports, given as module strings or paths. Direct dependencies
+// matching testOnly are test roots and the other direct dependencies are
+// production roots; each module is scoped by which roots reach it. The main
+// module is ScopeBoth and modules unreachable from it are left unscoped.
+func (dg *DependencyGraph) ApplyTestScope(testOnly []string) {
+	isTestRoot := make(map[string]bool)
+	for _, query := range testOnly {
+		isTestRoot[query] = true
+	}
+
+	tree := dg.GetTree()
+	prod := make(map[string]bool)
+	test := make(map[string]bool)
+	for _, root := range dg.GetDirectDependencies(dg.MainModule) {
+		reached := prod
+		if isTestRoot[root.String()] || isTestRoot[root.Path] {
+			reached = test
+		}
+		for key := range reachableIn(tree, root.String(), nil) {
+			reached[key] = true
+		}
+	}
+
+	mainStr := dg.MainModule.String()
+	attach := func(module Module) Module {
+		switch key := module.String(); {
+		case key == mainStr, prod[key] && test[key]:
+			module.Scope = ScopeBoth
+		case prod[key]:
+			module.Scope = ScopeProd
+		case test[key]:
+			module.Scope = ScopeTest
+		default:
+			module.Scope = ""
+		}
+		return module
+	}
+
+	dg.MainModule = attach(dg.MainModule)
+	for i, dep := range dg.Dependencies {
+		dg.Dependencies[i].From = attach(dep.From)
+		dg.Dependencies[i].To = attach(dep.To)
+	}
+}
+
+// FilterScope returns a copy of the graph keeping only the modules whose
+// Scope is scope or ScopeBoth, along with the edges among them
+func (dg *DependencyGraph) FilterScope(scope Scope) *DependencyGraph {
+	kept := func(module Module) bool {
+		return module.Scope == scope || module.Scope == ScopeBoth
+	}
+	return dg.subgraph(func(dep Dependency) bool {
+		return kept(dep.From) && kept(dep.To)
+	})
+}
@@ -0,0 +1,44 @@
+package tangled
+
+import "testing"
+
+func TestDependencyGraph_ApplyTestScope(t *testing.T) {
+	graph := createTestGraph()
+	shared := Module{Path: "github.com/shared", Version: "v1.0.0"}
+	graph.AddDependency(Module{Path: "github.com/dep1", Version: "v1.0.0"}, shared)
+	graph.AddDependency(Module{Path: "github.com/dep2", Version: "v2.0.0"}, shared)
+
+	graph.ApplyTestScope([]string{"github.com/dep2"})
+
+	scopes := make(map[string]Scope)
+	for _, module := range graph.GetAllModules() {
+		scopes[module.Path] = module.Scope
+	}
+	want := map[string]Scope{
+		"github.com/example/main": ScopeBoth,
+		"github.com/dep1":         ScopeProd,
+		"github.com/subdep":       ScopeProd,
+		"github.com/dep2":         ScopeTest,
+		"github.com/shared":       ScopeBoth,
+	}
+	for path, scope := range want {
+		if scopes[path] != scope {
+			t.Errorf("%s scope = %q, want %q", path, scopes[path], scope)
+		}
+	}
+}
+
+func TestDependencyGraph_FilterScope(t *testing.T) {
+	graph := createTestGraph()
+	graph.ApplyTestScope([]string{"github.com/dep2@v2.0.0"})
+
+	for _, module := range graph.FilterScope(ScopeProd).GetAllModules() {
+		if module.Path == "github.com/dep2" {
+			t.Error("prod scope should exclude the test-only module")
+		}
+	}
+	test := graph.FilterScope(ScopeTest)
+	if len(test.Dependencies) != 1 || test.Dependencies[0].To.Path != "github.com/dep2" {
+		t.Errorf("test scope = %v, want only main -> dep2", test.Dependencies)
+	}
+}
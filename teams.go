@@ -0,0 +1,39 @@
+package tangled
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ParseTeamsFromFile reads a team ownership file and returns owning teams keyed by module path
+func ParseTeamsFromFile(filename string) (map[string]string, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseTeams(file)
+}
+
+// ParseTeams reads "module-path team" lines and returns owning teams keyed by
+// module path. Blank lines and lines starting with # are ignored.
+func ParseTeams(reader io.Reader) (map[string]string, error) {
+	return parsePairs(reader)
+}
+
+// FilterByTeam returns a copy of the graph keeping only modules owned by
+// team, compared case-insensitively, along with the edges among them and the
+// edges from the main module to them
+func (dg *DependencyGraph) FilterByTeam(teams map[string]string, team string) *DependencyGraph {
+	owned := func(module Module) bool {
+		return strings.EqualFold(teams[module.Path], team)
+	}
+	mainStr := dg.MainModule.String()
+
+	return dg.subgraph(func(dep Dependency) bool {
+		return owned(dep.To) && (owned(dep.From) || dep.From.String() == mainStr)
+	})
+}
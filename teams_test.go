@@ -0,0 +1,42 @@
+package tangled
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTeams(t *testing.T) {
+	input := "# owners\ngithub.com/dep1 platform\n\ngithub.com/dep2 payments\n"
+
+	teams, err := ParseTeams(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseTeams() error = %v", err)
+	}
+	if teams["github.com/dep1"] != "platform" || teams["github.com/dep2"] != "payments" {
+		t.Errorf("ParseTeams() = %v", teams)
+	}
+}
+
+func TestDependencyGraph_FilterByTeam(t *testing.T) {
+	graph := createTestGraph()
+	teams := map[string]string{
+		"github.com/dep1":   "platform",
+		"github.com/subdep": "platform",
+		"github.com/dep2":   "payments",
+	}
+
+	filtered := graph.FilterByTeam(teams, "Platform")
+
+	modules := filtered.GetAllModules()
+	if len(modules) != 3 {
+		t.Fatalf("modules = %v, want main, dep1 and subdep", modules)
+	}
+	for _, module := range modules {
+		if module != graph.MainModule && teams[module.Path] != "platform" {
+			t.Errorf("module %v should have been filtered out", module)
+		}
+	}
+	if len(filtered.Dependencies) != 2 {
+		t.Errorf("edges = %v, want the root edge and the edge among team modules", filtered.Dependencies)
+	}
+}
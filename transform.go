@@ -0,0 +1,422 @@
+package tangled
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// subgraph returns a new graph with the same main module containing only the
+// dependencies accepted by keep, in their original order
+func (dg *DependencyGraph) subgraph(keep func(dep Dependency) bool) *DependencyGraph {
+	result := NewDependencyGraph(dg.MainModule)
+	result.via = dg.via // never modified once built, so copies share it
+	for _, dep := range dg.Dependencies {
+		if keep(dep) {
+			result.addEdge(dep)
+		}
+	}
+	return result
+}
+
+// reachable returns the set of module strings reachable from start,
+// never entering any module in blocked
+func (dg *DependencyGraph) reachable(start string, blocked map[string]bool) map[string]bool {
+	return reachableIn(dg.GetTree(), start, blocked)
+}
+
+// reachableIn returns the set of keys reachable from start in an adjacency
+// map, never entering any key in blocked
+func reachableIn(tree map[string][]string, start string, blocked map[string]bool) map[string]bool {
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, child := range tree[current] {
+			if seen[child] || blocked[child] {
+				continue
+			}
+			seen[child] = true
+			queue = append(queue, child)
+		}
+	}
+
+	return seen
+}
+
+// SimulateRemoval returns a copy of the graph with module removed, along with
+// any modules that are no longer reachable from the main module as a result
+func (dg *DependencyGraph) SimulateRemoval(module Module) *DependencyGraph {
+	removed := module.String()
+	if removed == dg.MainModule.String() {
+		return NewDependencyGraph(dg.MainModule)
+	}
+
+	kept := dg.reachable(dg.MainModule.String(), map[string]bool{removed: true})
+	return dg.subgraph(func(dep Dependency) bool {
+		return kept[dep.From.String()] && kept[dep.To.String()]
+	})
+}
+
+// Exclude returns a copy of the graph without the modules whose path matches
+// any of prefixes, along with any modules no longer reachable from the main
+// module as a result. A prefix matches its own path and the paths beneath it;
+// a prefix ending in "/" matches only paths beneath it. The main module is
+// never excluded.
+func (dg *DependencyGraph) Exclude(prefixes []string) *DependencyGraph {
+	blocked := make(map[string]bool)
+	for _, module := range dg.GetAllModules() {
+		for _, prefix := range prefixes {
+			if hasPathPrefix(module.Path, prefix) {
+				blocked[module.String()] = true
+				break
+			}
+		}
+	}
+	delete(blocked, dg.MainModule.String())
+
+	kept := dg.reachable(dg.MainModule.String(), blocked)
+	return dg.subgraph(func(dep Dependency) bool {
+		return kept[dep.From.String()] && kept[dep.To.String()]
+	})
+}
+
+// Include returns a copy of the graph keeping only the main module and the
+// modules whose path matches any of prefixes, as in Exclude, along with the
+// edges among them
+func (dg *DependencyGraph) Include(prefixes []string) *DependencyGraph {
+	mainStr := dg.MainModule.String()
+	kept := func(module Module) bool {
+		if module.String() == mainStr {
+			return true
+		}
+		for _, prefix := range prefixes {
+			if hasPathPrefix(module.Path, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return dg.subgraph(func(dep Dependency) bool {
+		return kept(dep.From) && kept(dep.To)
+	})
+}
+
+// LimitDepth returns a copy of the graph keeping only modules at most depth
+// hops from the main module, along with the edges among them
+func (dg *DependencyGraph) LimitDepth(depth int) *DependencyGraph {
+	depths := dg.DepthMap()
+	within := func(module Module) bool {
+		d, ok := depths[module.String()]
+		return ok && d <= depth
+	}
+
+	return dg.subgraph(func(dep Dependency) bool {
+		return within(dep.From) && within(dep.To)
+	})
+}
+
+// LimitEdges returns a copy of the graph keeping at most n edges, preferring
+// those whose source is closest to the main module, along with the number of
+// edges dropped. Edges from unreachable modules are dropped first, ties are
+// broken by input order, and the kept edges stay in input order.
+func (dg *DependencyGraph) LimitEdges(n int) (*DependencyGraph, int) {
+	if len(dg.Dependencies) <= n {
+		return dg.subgraph(func(Dependency) bool { return true }), 0
+	}
+
+	depths := dg.DepthMap()
+	depthOf := func(dep Dependency) int {
+		if d, ok := depths[dep.From.String()]; ok {
+			return d
+		}
+		return math.MaxInt
+	}
+
+	order := make([]int, len(dg.Dependencies))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return depthOf(dg.Dependencies[order[i]]) < depthOf(dg.Dependencies[order[j]])
+	})
+	keep := make(map[int]bool, max(n, 0))
+	for _, i := range order[:max(n, 0)] {
+		keep[i] = true
+	}
+
+	result := NewDependencyGraph(dg.MainModule)
+	result.via = dg.via
+	for i, dep := range dg.Dependencies {
+		if keep[i] {
+			result.addEdge(dep)
+		}
+	}
+	return result, len(dg.Dependencies) - len(keep)
+}
+
+// NearLeaves returns a copy of the graph keeping only modules at most hops
+// dependency edges above a leaf, a module with no outgoing edges, along with
+// the edges among them. Distances are found by a breadth-first search from
+// every leaf along reversed edges.
+func (dg *DependencyGraph) NearLeaves(hops int) *DependencyGraph {
+	reverse := make(map[string][]string)
+	for _, dep := range dg.Dependencies {
+		toStr := dep.To.String()
+		reverse[toStr] = append(reverse[toStr], dep.From.String())
+	}
+
+	distances := make(map[string]int)
+	var queue []string
+	for _, leaf := range dg.LeafModules() {
+		distances[leaf.String()] = 0
+		queue = append(queue, leaf.String())
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if distances[current] == hops {
+			continue
+		}
+		for _, parent := range reverse[current] {
+			if _, seen := distances[parent]; seen {
+				continue
+			}
+			distances[parent] = distances[current] + 1
+			queue = append(queue, parent)
+		}
+	}
+
+	return dg.subgraph(func(dep Dependency) bool {
+		_, fromOK := distances[dep.From.String()]
+		_, toOK := distances[dep.To.String()]
+		return fromOK && toOK
+	})
+}
+
+// hasPathPrefix reports whether path is prefix or lies beneath it
+func hasPathPrefix(path, prefix string) bool {
+	if strings.HasSuffix(prefix, "/") {
+		return strings.HasPrefix(path, prefix)
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// RootSubtree returns a copy of the graph keeping only the edge from the main
+// module to child and the edges among the modules child reaches without
+// passing back through the main module
+func (dg *DependencyGraph) RootSubtree(child Module) *DependencyGraph {
+	mainStr, childStr := dg.MainModule.String(), child.String()
+	within := reachableIn(dg.GetTree(), childStr, map[string]bool{mainStr: true})
+
+	return dg.subgraph(func(dep Dependency) bool {
+		from, to := dep.From.String(), dep.To.String()
+		if from == mainStr {
+			return to == childStr
+		}
+		return within[from] && within[to]
+	})
+}
+
+// CollapseChains returns a copy of the graph in which linear chains are
+// compressed into single edges. A module is an intermediate link when it has
+// exactly one incoming and one outgoing edge and is not the main module;
+// each run of such modules is replaced by one edge, and Via lists them. A
+// chain is left as-is when its collapsed edge would join the same modules as
+// another edge, so every edge keeps its own Via.
+func (dg *DependencyGraph) CollapseChains() *DependencyGraph {
+	inDegree := make(map[string]int)
+	outEdges := make(map[string][]Dependency)
+	for _, dep := range dg.Dependencies {
+		inDegree[dep.To.String()]++
+		outEdges[dep.From.String()] = append(outEdges[dep.From.String()], dep)
+	}
+
+	mainStr := dg.MainModule.String()
+	intermediate := func(moduleStr string) bool {
+		return moduleStr != mainStr && inDegree[moduleStr] == 1 && len(outEdges[moduleStr]) == 1
+	}
+
+	// Follow each edge that does not start inside a chain to the end of it
+	var chains [][]Dependency
+	collapsed := make(map[string]bool)
+	endpoints := make(map[string]int)
+	for _, dep := range dg.Dependencies {
+		if intermediate(dep.From.String()) {
+			continue
+		}
+
+		chain := []Dependency{dep}
+		for to := dep.To.String(); intermediate(to); to = chain[len(chain)-1].To.String() {
+			collapsed[to] = true
+			chain = append(chain, outEdges[to][0])
+		}
+		chains = append(chains, chain)
+		endpoints[dep.From.String()+" "+chain[len(chain)-1].To.String()]++
+	}
+
+	result := NewDependencyGraph(dg.MainModule)
+	for _, chain := range chains {
+		first, last := chain[0], chain[len(chain)-1]
+		edge := Dependency{From: first.From, To: last.To, SourceLine: first.SourceLine}
+		if len(chain) == 1 || endpoints[edgeKey(edge)] > 1 {
+			for _, dep := range chain {
+				result.addEdge(dep)
+				result.setVia(dep, dg.Via(dep))
+			}
+			continue
+		}
+
+		via := slices.Clone(dg.Via(first))
+		for _, dep := range chain[1:] {
+			via = append(via, dep.From)
+			via = append(via, dg.Via(dep)...)
+		}
+		result.addEdge(edge)
+		result.setVia(edge, via)
+	}
+
+	// Keep edges of isolated rings made entirely of intermediate modules
+	for _, dep := range dg.Dependencies {
+		if intermediate(dep.From.String()) && !collapsed[dep.From.String()] {
+			result.addEdge(dep)
+			result.setVia(dep, dg.Via(dep))
+		}
+	}
+
+	return result
+}
+
+// EgoNetwork returns the subgraph of modules within radius hops of center,
+// following edges in both directions. The center becomes the main module of
+// the returned graph so renderers highlight it.
+func (dg *DependencyGraph) EgoNetwork(center Module, radius int) (*DependencyGraph, error) {
+	if radius < 0 {
+		return nil, fmt.Errorf("ego radius must not be negative, got %d", radius)
+	}
+
+	included := map[string]bool{center.String(): true}
+	frontier := []Module{center}
+
+	for hop := 0; hop < radius; hop++ {
+		var next []Module
+		for _, module := range frontier {
+			neighbors := append(dg.GetDirectDependencies(module), dg.GetDependents(module)...)
+			for _, neighbor := range neighbors {
+				if !included[neighbor.String()] {
+					included[neighbor.String()] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	ego := dg.subgraph(func(dep Dependency) bool {
+		return included[dep.From.String()] && included[dep.To.String()]
+	})
+	ego.MainModule = center
+	return ego, nil
+}
+
+// Changed returns a copy of the graph keeping only the modules whose path is
+// one of paths, such as those a go.mod diff touched, and their direct
+// dependencies and dependents, along with the edges among them. Paths match
+// exactly, so a changed module does not pull in its major version siblings.
+func (dg *DependencyGraph) Changed(paths []string) *DependencyGraph {
+	changed := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		changed[path] = true
+	}
+
+	included := make(map[string]bool)
+	for _, dep := range dg.Dependencies {
+		if changed[dep.From.Path] || changed[dep.To.Path] {
+			included[dep.From.String()] = true
+			included[dep.To.String()] = true
+		}
+	}
+
+	return dg.subgraph(func(dep Dependency) bool {
+		return included[dep.From.String()] && included[dep.To.String()]
+	})
+}
+
+// HideRootEdges returns a copy of the graph without the edges that originate
+// from the main module; modules reachable only through them become disconnected
+func (dg *DependencyGraph) HideRootEdges() *DependencyGraph {
+	mainStr := dg.MainModule.String()
+	return dg.subgraph(func(dep Dependency) bool {
+		return dep.From.String() != mainStr
+	})
+}
+
+// Transpose returns a copy of the graph with every edge reversed, so edges
+// point from a module to the modules that depend on it. The main module is
+// unchanged and becomes a sink. Collapsed chains are reversed along with
+// their edge, and source lines are kept.
+func (dg *DependencyGraph) Transpose() *DependencyGraph {
+	result := NewDependencyGraph(dg.MainModule)
+	for _, dep := range dg.Dependencies {
+		reversed := Dependency{From: dep.To, To: dep.From, SourceLine: dep.SourceLine}
+		via := slices.Clone(dg.Via(dep))
+		slices.Reverse(via)
+		result.addEdge(reversed)
+		result.setVia(reversed, via)
+	}
+	return result
+}
+
+// Flatten returns a star-shaped graph with a direct edge from the main module
+// to every module it transitively depends on, and no other edges
+func (dg *DependencyGraph) Flatten() *DependencyGraph {
+	result := NewDependencyGraph(dg.MainModule)
+	for _, module := range dg.GetTransitiveDependencies(dg.MainModule) {
+		result.AddDependency(dg.MainModule, module)
+	}
+	return result
+}
+
+// TopCentral returns a copy of the graph keeping only the main module and the
+// n most central other modules by PageRank, with the edges among them. Ties
+// are broken by module order.
+func (dg *DependencyGraph) TopCentral(n int) *DependencyGraph {
+	centrality := dg.Centrality()
+	return dg.topBy(n, func(moduleStr string) float64 { return centrality[moduleStr] })
+}
+
+// TopCentralBy is TopCentral ranking modules by DegreeCentrality(mode), the
+// measure HTMLRenderer sizes nodes by, instead of PageRank
+func (dg *DependencyGraph) TopCentralBy(n int, mode CentralityMode) *DependencyGraph {
+	degrees := dg.DegreeCentrality(mode)
+	return dg.topBy(n, func(moduleStr string) float64 { return float64(degrees[moduleStr]) })
+}
+
+// topBy keeps the main module and the n other modules with the highest score
+func (dg *DependencyGraph) topBy(n int, score func(moduleStr string) float64) *DependencyGraph {
+	mainStr := dg.MainModule.String()
+
+	var candidates []string
+	for _, module := range dg.GetAllModules() {
+		if moduleStr := module.String(); moduleStr != mainStr {
+			candidates = append(candidates, moduleStr)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return score(candidates[i]) > score(candidates[j])
+	})
+
+	keep := map[string]bool{mainStr: true}
+	for _, moduleStr := range candidates[:min(n, len(candidates))] {
+		keep[moduleStr] = true
+	}
+	return dg.subgraph(func(dep Dependency) bool {
+		return keep[dep.From.String()] && keep[dep.To.String()]
+	})
+}
@@ -0,0 +1,413 @@
+package tangled
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDependencyGraph_SimulateRemoval(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	gateway := Module{Path: "github.com/gateway", Version: "v1.0.0"}
+	orphan := Module{Path: "github.com/orphan", Version: "v1.0.0"}
+	shared := Module{Path: "github.com/shared", Version: "v1.0.0"}
+	other := Module{Path: "github.com/other", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, gateway)
+	graph.AddDependency(mainModule, other)
+	graph.AddDependency(gateway, orphan)
+	graph.AddDependency(gateway, shared)
+	graph.AddDependency(other, shared)
+
+	result := graph.SimulateRemoval(gateway)
+
+	present := make(map[Module]bool)
+	for _, module := range result.GetAllModules() {
+		present[module] = true
+	}
+
+	if present[gateway] {
+		t.Error("removed module should not be present")
+	}
+	if present[orphan] {
+		t.Error("module only reachable through the removed module should be dropped")
+	}
+	if !present[shared] || !present[other] {
+		t.Error("modules still reachable from the root should be kept")
+	}
+	if len(result.Dependencies) != 2 {
+		t.Errorf("Dependencies length = %d, want 2", len(result.Dependencies))
+	}
+	if len(graph.Dependencies) != 5 {
+		t.Error("original graph should not be modified")
+	}
+}
+
+func TestDependencyGraph_Exclude(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	corp := Module{Path: "github.com/corp/lib", Version: "v1.0.0"}
+	corpChild := Module{Path: "github.com/corp/lib/v2", Version: "v2.0.0"}
+	similar := Module{Path: "github.com/corp/library", Version: "v1.0.0"}
+	behind := Module{Path: "github.com/behind", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, corp)
+	graph.AddDependency(mainModule, corpChild)
+	graph.AddDependency(mainModule, similar)
+	graph.AddDependency(corp, behind)
+
+	present := make(map[Module]bool)
+	for _, module := range graph.Exclude([]string{"github.com/corp/lib", "github.com/example/main"}).GetAllModules() {
+		present[module] = true
+	}
+	if present[corp] || present[corpChild] {
+		t.Error("modules at or beneath the prefix should be excluded")
+	}
+	if present[behind] {
+		t.Error("modules only reachable through excluded modules should be dropped")
+	}
+	if !present[similar] || !present[mainModule] {
+		t.Error("the main module and paths merely sharing a string prefix should be kept")
+	}
+}
+
+func TestDependencyGraph_CollapseChains(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+	d := Module{Path: "example.com/d", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, c)
+	graph.AddDependency(c, d)
+
+	result := graph.CollapseChains()
+	if len(result.Dependencies) != 1 {
+		t.Fatalf("Dependencies length = %d, want 1", len(result.Dependencies))
+	}
+
+	edge := result.Dependencies[0]
+	if edge.From != a || edge.To != d {
+		t.Errorf("collapsed edge = %v -> %v, want %v -> %v", edge.From, edge.To, a, d)
+	}
+	if label := result.ViaLabel(edge); label != "example.com/b@v1.0.0 → example.com/c@v1.0.0" {
+		t.Errorf("ViaLabel() = %q", label)
+	}
+
+	var buf bytes.Buffer
+	if err := NewGraphvizRenderer().Render(result, &buf); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `[label="example.com/b@v1.0.0 → example.com/c@v1.0.0"]`) {
+		t.Errorf("DOT output should label the collapsed edge, got:\n%s", buf.String())
+	}
+}
+
+func TestDependencyGraph_CollapseChainsKeepsBranches(t *testing.T) {
+	graph := createTestGraph()
+
+	result := graph.CollapseChains()
+	if len(result.Dependencies) != 2 {
+		t.Fatalf("Dependencies length = %d, want 2", len(result.Dependencies))
+	}
+	for _, dep := range result.Dependencies {
+		if dep.To.Path == "github.com/dep1" {
+			t.Errorf("dep1 has one in and one out edge and should be collapsed")
+		}
+	}
+}
+
+func TestDependencyGraph_CollapseChainsParallel(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+	d := Module{Path: "example.com/d", Version: "v1.0.0"}
+
+	// a -> b -> d and a -> c -> d would both collapse to a -> d
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, d)
+	graph.AddDependency(a, c)
+	graph.AddDependency(c, d)
+
+	result := graph.CollapseChains()
+	if !reflect.DeepEqual(result.Dependencies, graph.Dependencies) {
+		t.Errorf("Dependencies = %v, want parallel chains kept as-is", result.Dependencies)
+	}
+}
+
+func TestDependencyGraph_ViaSurvivesTransforms(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+	d := Module{Path: "example.com/d", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, c)
+	graph.AddDependency(c, d)
+
+	limited, _ := graph.CollapseChains().LimitEdges(10)
+	if label := limited.ViaLabel(Dependency{From: a, To: d}); label != "example.com/b@v1.0.0 → example.com/c@v1.0.0" {
+		t.Errorf("ViaLabel() after LimitEdges = %q", label)
+	}
+
+	transposed := limited.Transpose()
+	if label := transposed.ViaLabel(Dependency{From: d, To: a}); label != "example.com/c@v1.0.0 → example.com/b@v1.0.0" {
+		t.Errorf("ViaLabel() after Transpose = %q", label)
+	}
+}
+
+func TestDependencyGraph_LimitEdges(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+	d := Module{Path: "example.com/d", Version: "v1.0.0"}
+	orphan := Module{Path: "example.com/orphan"}
+
+	// Deeper and unreachable edges come first in the input
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(orphan, b)
+	graph.AddDependency(c, d)
+	graph.AddDependency(b, c)
+	graph.AddDependency(a, b)
+	graph.AddDependency(a, d)
+
+	for n := 0; n <= len(graph.Dependencies)+1; n++ {
+		limited, dropped := graph.LimitEdges(n)
+		if len(limited.Dependencies) > n {
+			t.Errorf("LimitEdges(%d) kept %d edges", n, len(limited.Dependencies))
+		}
+		if len(limited.Dependencies)+dropped != len(graph.Dependencies) {
+			t.Errorf("LimitEdges(%d) kept %d and dropped %d of %d edges", n, len(limited.Dependencies), dropped, len(graph.Dependencies))
+		}
+	}
+
+	limited, dropped := graph.LimitEdges(3)
+	want := []Dependency{{From: b, To: c}, {From: a, To: b}, {From: a, To: d}}
+	if !reflect.DeepEqual(limited.Dependencies, want) || dropped != 2 {
+		t.Errorf("LimitEdges(3) = %v, %d, want %v, 2", limited.Dependencies, dropped, want)
+	}
+}
+
+func TestDependencyGraph_Changed(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+	c2 := Module{Path: "example.com/c/v2", Version: "v2.0.0"}
+	d := Module{Path: "example.com/d", Version: "v1.0.0"}
+	e := Module{Path: "example.com/e", Version: "v1.0.0"}
+
+	// a -> b -> c -> d -> e, with a -> c/v2 and b -> d, where only c changed
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, c)
+	graph.AddDependency(c, d)
+	graph.AddDependency(d, e)
+	graph.AddDependency(a, c2)
+	graph.AddDependency(b, d)
+
+	changed := graph.Changed([]string{"example.com/c"})
+	want := []Dependency{{From: b, To: c}, {From: c, To: d}, {From: b, To: d}}
+	if !reflect.DeepEqual(changed.Dependencies, want) {
+		t.Errorf("Changed() = %v, want %v", changed.Dependencies, want)
+	}
+	if changed.MainModule != a {
+		t.Errorf("Changed() MainModule = %v, want %v", changed.MainModule, a)
+	}
+}
+
+func TestDependencyGraph_EgoNetwork(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+	d := Module{Path: "example.com/d", Version: "v1.0.0"}
+	e := Module{Path: "example.com/e", Version: "v1.0.0"}
+
+	// a -> b -> c -> d -> e, centered on c
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, c)
+	graph.AddDependency(c, d)
+	graph.AddDependency(d, e)
+
+	modulesAt := func(radius int) map[Module]bool {
+		ego, err := graph.EgoNetwork(c, radius)
+		if err != nil {
+			t.Fatalf("EgoNetwork() error = %v", err)
+		}
+		if ego.MainModule != c {
+			t.Errorf("ego MainModule = %v, want %v", ego.MainModule, c)
+		}
+		present := make(map[Module]bool)
+		for _, module := range ego.GetAllModules() {
+			present[module] = true
+		}
+		return present
+	}
+
+	one := modulesAt(1)
+	if !one[b] || !one[c] || !one[d] {
+		t.Errorf("radius 1 should include direct neighbors, got %v", one)
+	}
+	if one[a] || one[e] {
+		t.Errorf("radius 1 should exclude second-hop neighbors, got %v", one)
+	}
+
+	two := modulesAt(2)
+	if !two[a] || !two[e] {
+		t.Errorf("radius 2 should include second-hop neighbors, got %v", two)
+	}
+
+	if _, err := graph.EgoNetwork(c, -1); err == nil {
+		t.Error("EgoNetwork() should reject a negative radius")
+	}
+}
+
+func TestDependencyGraph_HideRootEdges(t *testing.T) {
+	graph := createTestGraph()
+
+	hidden := graph.HideRootEdges()
+	if hidden.MainModule != graph.MainModule {
+		t.Errorf("MainModule = %v, want %v", hidden.MainModule, graph.MainModule)
+	}
+	for _, dep := range hidden.Dependencies {
+		if dep.From == graph.MainModule {
+			t.Errorf("edge from the main module should be dropped: %v -> %v", dep.From, dep.To)
+		}
+	}
+	if len(hidden.Dependencies) != 1 {
+		t.Errorf("deeper edges should be kept, got %v", hidden.Dependencies)
+	}
+}
+
+func TestDependencyGraph_Flatten(t *testing.T) {
+	graph := createTestGraph()
+
+	flat := graph.Flatten()
+	if len(flat.Dependencies) != 3 {
+		t.Errorf("flattened edges = %d, want one per reachable module", len(flat.Dependencies))
+	}
+	for _, dep := range flat.Dependencies {
+		if dep.From != graph.MainModule {
+			t.Errorf("edge should originate from the main module: %v -> %v", dep.From, dep.To)
+		}
+	}
+}
+
+func TestDependencyGraph_TopCentral(t *testing.T) {
+	graph := syntheticGraph(4, 10)
+
+	for _, n := range []int{0, 1, 5, 100} {
+		top := graph.TopCentral(n)
+		modules := top.GetAllModules()
+		if len(modules) > n+1 {
+			t.Errorf("TopCentral(%d) kept %d modules, want at most %d", n, len(modules), n+1)
+		}
+		if top.MainModule != graph.MainModule {
+			t.Errorf("TopCentral(%d) MainModule = %v, want %v", n, top.MainModule, graph.MainModule)
+		}
+	}
+}
+
+func TestDependencyGraph_TopCentralBy(t *testing.T) {
+	graph := createTestGraph()
+	dep2 := Module{Path: "github.com/dep2", Version: "v2.0.0"}
+	subdep := Module{Path: "github.com/subdep", Version: "v1.0.0"}
+	graph.AddDependency(dep2, subdep)
+
+	// subdep has the most incoming edges and none outgoing
+	if got := graph.TopCentralBy(2, CentralityIn).GetAllModules(); !slices.Contains(got, subdep) {
+		t.Errorf("TopCentralBy(2, in) = %v, want subdep kept", got)
+	}
+	if got := graph.TopCentralBy(2, CentralityOut).GetAllModules(); slices.Contains(got, subdep) || !slices.Contains(got, dep2) {
+		t.Errorf("TopCentralBy(2, out) = %v, want dep1 and dep2 kept", got)
+	}
+}
+
+func TestDependencyGraph_Include(t *testing.T) {
+	graph := createTestGraph()
+
+	result := graph.Include([]string{"github.com/dep1", "github.com/subdep"})
+	if len(result.Dependencies) != 2 {
+		t.Errorf("Dependencies = %v, want main -> dep1 and dep1 -> subdep", result.Dependencies)
+	}
+	for _, dep := range result.Dependencies {
+		if dep.To.Path == "github.com/dep2" {
+			t.Error("dep2 should not be included")
+		}
+	}
+}
+
+func TestDependencyGraph_RootSubtree(t *testing.T) {
+	graph := createTestGraph()
+	dep1 := Module{Path: "github.com/dep1", Version: "v1.0.0"}
+
+	result := graph.RootSubtree(dep1)
+	var edges []string
+	for _, dep := range result.Dependencies {
+		edges = append(edges, edgeKey(dep))
+	}
+	want := []string{
+		"github.com/example/main github.com/dep1@v1.0.0",
+		"github.com/dep1@v1.0.0 github.com/subdep@v1.0.0",
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("RootSubtree(dep1) edges = %v, want %v", edges, want)
+	}
+}
+
+func TestDependencyGraph_LimitDepth(t *testing.T) {
+	graph := createTestGraph()
+
+	if got := len(graph.LimitDepth(1).Dependencies); got != 2 {
+		t.Errorf("LimitDepth(1) kept %d edges, want the 2 direct edges", got)
+	}
+	if got := len(graph.LimitDepth(2).Dependencies); got != 3 {
+		t.Errorf("LimitDepth(2) kept %d edges, want all 3", got)
+	}
+}
+
+func TestDependencyGraph_NearLeaves(t *testing.T) {
+	graph := createTestGraph()
+	leaf := Module{Path: "github.com/leaf", Version: "v1.0.0"}
+	graph.AddDependency(Module{Path: "github.com/subdep", Version: "v1.0.0"}, leaf)
+
+	result := graph.NearLeaves(1)
+	var edges []string
+	for _, dep := range result.Dependencies {
+		edges = append(edges, edgeKey(dep))
+	}
+	want := []string{
+		"github.com/example/main github.com/dep2@v2.0.0",
+		"github.com/subdep@v1.0.0 github.com/leaf@v1.0.0",
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("NearLeaves(1) edges = %v, want leaves and their direct parents %v", edges, want)
+	}
+}
+
+func TestDependencyGraph_Transpose(t *testing.T) {
+	graph := createTestGraph()
+
+	transposed := graph.Transpose()
+	if transposed.MainModule != graph.MainModule {
+		t.Errorf("MainModule = %v, want %v", transposed.MainModule, graph.MainModule)
+	}
+	if len(transposed.Dependencies) != len(graph.Dependencies) {
+		t.Fatalf("transposed %d edges, want %d", len(transposed.Dependencies), len(graph.Dependencies))
+	}
+	for i, dep := range transposed.Dependencies {
+		original := graph.Dependencies[i]
+		if dep.From != original.To || dep.To != original.From {
+			t.Errorf("edge %d = %s -> %s, want the reverse of %s -> %s", i, dep.From, dep.To, original.From, original.To)
+		}
+	}
+	if deps := transposed.GetDirectDependencies(transposed.MainModule); len(deps) != 0 {
+		t.Errorf("main module should be a sink, has dependencies %v", deps)
+	}
+}
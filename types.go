@@ -3,12 +3,23 @@ package tangled
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 )
 
 // Module represents a Go module with its path and version
 type Module struct {
 	Path    string
 	Version string
+	License string // SPDX license identifier, when known
+	Scope   Scope  // whether production code or only tests need it, when known
+	Vulns   string // comma-separated IDs of known vulnerabilities, such as GO-2023-1234
+}
+
+// Date returns the commit time embedded in the module's pseudo-version, with
+// ok false for tagged releases. No network lookups are made.
+func (m Module) Date() (time.Time, bool) {
+	return PseudoVersionTime(m.Version)
 }
 
 // String returns the string representation of a module
@@ -23,6 +34,16 @@ func (m Module) String() string {
 type Dependency struct {
 	From Module
 	To   Module
+
+	// SourceLine is the input line this edge was parsed from, or zero when
+	// the edge was constructed programmatically
+	SourceLine int
+}
+
+// IsSelfLoop reports whether the edge joins a module path to itself,
+// regardless of version
+func (d Dependency) IsSelfLoop() bool {
+	return d.From.Path == d.To.Path
 }
 
 // DependencyGraph represents the complete dependency graph
@@ -30,6 +51,7 @@ type DependencyGraph struct {
 	MainModule   Module
 	Dependencies []Dependency
 	tree         map[string][]string // cached tree structure for visualization
+	via          map[string][]Module // modules collapsed into an edge, keyed by edgeKey
 }
 
 // NewDependencyGraph creates a new dependency graph
@@ -48,6 +70,40 @@ func (dg *DependencyGraph) AddDependency(from, to Module) {
 	dg.tree = make(map[string][]string)
 }
 
+// addEdge appends a dependency as-is, preserving any extra edge information
+func (dg *DependencyGraph) addEdge(dep Dependency) {
+	dg.Dependencies = append(dg.Dependencies, dep)
+	// Invalidate cached tree
+	dg.tree = make(map[string][]string)
+}
+
+// Via returns the intermediate modules that CollapseChains folded into an
+// edge, in order from its source, or nil if the edge is not a collapsed chain
+func (dg *DependencyGraph) Via(dep Dependency) []Module {
+	return dg.via[edgeKey(dep)]
+}
+
+// ViaLabel returns the modules collapsed into an edge as an edge label
+func (dg *DependencyGraph) ViaLabel(dep Dependency) string {
+	via := dg.Via(dep)
+	parts := make([]string, len(via))
+	for i, module := range via {
+		parts[i] = module.String()
+	}
+	return strings.Join(parts, " → ")
+}
+
+// setVia records the modules collapsed into an edge of a graph being built
+func (dg *DependencyGraph) setVia(dep Dependency, via []Module) {
+	if len(via) == 0 {
+		return
+	}
+	if dg.via == nil {
+		dg.via = make(map[string][]Module)
+	}
+	dg.via[edgeKey(dep)] = via
+}
+
 // GetDirectDependencies returns all direct dependencies of a module
 func (dg *DependencyGraph) GetDirectDependencies(module Module) []Module {
 	var deps []Module
@@ -61,6 +117,64 @@ func (dg *DependencyGraph) GetDirectDependencies(module Module) []Module {
 	return deps
 }
 
+// GetDependents returns all modules that directly depend on a module
+func (dg *DependencyGraph) GetDependents(module Module) []Module {
+	var dependents []Module
+	moduleStr := module.String()
+
+	for _, dep := range dg.Dependencies {
+		if dep.To.String() == moduleStr {
+			dependents = append(dependents, dep.From)
+		}
+	}
+	return dependents
+}
+
+// GetTransitiveDependencies returns every module reachable from a module,
+// excluding the module itself, in sorted order
+func (dg *DependencyGraph) GetTransitiveDependencies(module Module) []Module {
+	start := module.String()
+	seen := dg.reachable(start, nil)
+
+	var deps []Module
+	for _, m := range dg.GetAllModules() {
+		if key := m.String(); key != start && seen[key] {
+			deps = append(deps, m)
+		}
+	}
+	return deps
+}
+
+// FindModule looks up a module by its full path@version string, falling back
+// to the first module (in sorted order) whose path matches
+func (dg *DependencyGraph) FindModule(query string) (Module, bool) {
+	modules := dg.GetAllModules()
+	for _, module := range modules {
+		if module.String() == query {
+			return module, true
+		}
+	}
+	for _, module := range modules {
+		if module.Path == query {
+			return module, true
+		}
+	}
+	return Module{}, false
+}
+
+// LeafModules returns all modules that have no outgoing dependencies, sorted
+func (dg *DependencyGraph) LeafModules() []Module {
+	tree := dg.GetTree()
+
+	var leaves []Module
+	for _, module := range dg.GetAllModules() {
+		if len(tree[module.String()]) == 0 {
+			leaves = append(leaves, module)
+		}
+	}
+	return leaves
+}
+
 // GetAllModules returns all unique modules in the graph
 func (dg *DependencyGraph) GetAllModules() []Module {
 	moduleSet := make(map[string]Module)
@@ -85,6 +199,66 @@ func (dg *DependencyGraph) GetAllModules() []Module {
 	return modules
 }
 
+// DepthMap returns the shortest distance from the main module to every
+// reachable module, keyed by module string. Unreachable modules are omitted.
+func (dg *DependencyGraph) DepthMap() map[string]int {
+	tree := dg.GetTree()
+	root := dg.MainModule.String()
+	depths := map[string]int{root: 0}
+	queue := []string{root}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, child := range tree[current] {
+			if _, seen := depths[child]; seen {
+				continue
+			}
+			depths[child] = depths[current] + 1
+			queue = append(queue, child)
+		}
+	}
+
+	return depths
+}
+
+// Canonicalize sorts the dependencies by source then target module so that
+// the tree, and therefore every renderer, is independent of input order
+func (dg *DependencyGraph) Canonicalize() {
+	sort.SliceStable(dg.Dependencies, func(i, j int) bool {
+		fromI, fromJ := dg.Dependencies[i].From.String(), dg.Dependencies[j].From.String()
+		if fromI != fromJ {
+			return fromI < fromJ
+		}
+		return dg.Dependencies[i].To.String() < dg.Dependencies[j].To.String()
+	})
+	// Invalidate cached tree so children are rebuilt in sorted order
+	dg.tree = make(map[string][]string)
+}
+
+// WalkEdges calls fn for every dependency in insertion order, stopping at
+// and returning the first error fn returns
+func (dg *DependencyGraph) WalkEdges(fn func(Dependency) error) error {
+	for _, dep := range dg.Dependencies {
+		if err := fn(dep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkModules calls fn for every unique module in sorted order, stopping at
+// and returning the first error fn returns
+func (dg *DependencyGraph) WalkModules(fn func(Module) error) error {
+	for _, module := range dg.GetAllModules() {
+		if err := fn(module); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // buildTree builds the tree structure for visualization
 func (dg *DependencyGraph) buildTree() {
 	if len(dg.tree) > 0 {
@@ -107,3 +281,14 @@ func (dg *DependencyGraph) GetTree() map[string][]string {
 	dg.buildTree()
 	return dg.tree
 }
+
+// AdjacencyMap returns the direct dependencies of every module with outgoing
+// edges, keyed by Module rather than module string, in edge order. Unlike
+// GetTree the result is freshly built and may be modified by the caller.
+func (dg *DependencyGraph) AdjacencyMap() map[Module][]Module {
+	adjacency := make(map[Module][]Module)
+	for _, dep := range dg.Dependencies {
+		adjacency[dep.From] = append(adjacency[dep.From], dep.To)
+	}
+	return adjacency
+}
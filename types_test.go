@@ -0,0 +1,211 @@
+package tangled
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDependencyGraph_LeafModules(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+
+	t.Run("chain", func(t *testing.T) {
+		graph := NewDependencyGraph(a)
+		graph.AddDependency(a, b)
+		graph.AddDependency(b, c)
+
+		leaves := graph.LeafModules()
+		if len(leaves) != 1 || leaves[0] != c {
+			t.Errorf("LeafModules() = %v, want [%v]", leaves, c)
+		}
+	})
+
+	t.Run("root without dependencies", func(t *testing.T) {
+		graph := NewDependencyGraph(a)
+
+		leaves := graph.LeafModules()
+		if len(leaves) != 1 || leaves[0] != a {
+			t.Errorf("LeafModules() = %v, want [%v]", leaves, a)
+		}
+	})
+}
+
+func TestDependencyGraph_GetDependents(t *testing.T) {
+	graph := createTestGraph()
+	dep1 := Module{Path: "github.com/dep1", Version: "v1.0.0"}
+
+	dependents := graph.GetDependents(dep1)
+	if len(dependents) != 1 || dependents[0] != graph.MainModule {
+		t.Errorf("GetDependents() = %v, want [%v]", dependents, graph.MainModule)
+	}
+}
+
+func TestDependencyGraph_GetTransitiveDependencies(t *testing.T) {
+	a := Module{Path: "example.com/a"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	c := Module{Path: "example.com/c", Version: "v1.0.0"}
+
+	graph := NewDependencyGraph(a)
+	graph.AddDependency(a, b)
+	graph.AddDependency(b, c)
+	graph.AddDependency(c, a)
+
+	deps := graph.GetTransitiveDependencies(a)
+	if len(deps) != 2 || deps[0] != b || deps[1] != c {
+		t.Errorf("GetTransitiveDependencies() = %v, want [%v %v]", deps, b, c)
+	}
+}
+
+func TestDependencyGraph_FindModule(t *testing.T) {
+	graph := createTestGraph()
+
+	tests := []struct {
+		query string
+		want  Module
+		found bool
+	}{
+		{query: "github.com/dep1@v1.0.0", want: Module{Path: "github.com/dep1", Version: "v1.0.0"}, found: true},
+		{query: "github.com/dep2", want: Module{Path: "github.com/dep2", Version: "v2.0.0"}, found: true},
+		{query: "github.com/missing", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, found := graph.FindModule(tt.query)
+			if found != tt.found || got != tt.want {
+				t.Errorf("FindModule() = %v, %v, want %v, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestDependencyGraph_WalkEdges(t *testing.T) {
+	graph := createTestGraph()
+
+	var visited int
+	err := graph.WalkEdges(func(Dependency) error {
+		visited++
+		return nil
+	})
+	if err != nil || visited != len(graph.Dependencies) {
+		t.Errorf("WalkEdges() visited %d edges with error %v, want %d and nil", visited, err, len(graph.Dependencies))
+	}
+
+	stop := errors.New("stop")
+	visited = 0
+	err = graph.WalkEdges(func(Dependency) error {
+		visited++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("WalkEdges() error = %v, want %v", err, stop)
+	}
+	if visited != 1 {
+		t.Errorf("WalkEdges() should stop after the first error, visited %d edges", visited)
+	}
+}
+
+func TestDependencyGraph_WalkModules(t *testing.T) {
+	graph := createTestGraph()
+
+	var visited []Module
+	err := graph.WalkModules(func(module Module) error {
+		visited = append(visited, module)
+		return nil
+	})
+	if err != nil || len(visited) != 4 {
+		t.Errorf("WalkModules() visited %d modules with error %v, want 4 and nil", len(visited), err)
+	}
+
+	stop := errors.New("stop")
+	count := 0
+	err = graph.WalkModules(func(Module) error {
+		count++
+		if count == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || count != 2 {
+		t.Errorf("WalkModules() should stop at the failing module, got error %v after %d modules", err, count)
+	}
+}
+
+func TestDependencyGraph_Canonicalize(t *testing.T) {
+	edges := []string{
+		"github.com/example/main github.com/dep1@v1.0.0",
+		"github.com/example/main github.com/dep2@v2.0.0",
+		"github.com/dep1@v1.0.0 github.com/subdep@v1.0.0",
+		"github.com/dep2@v2.0.0 github.com/subdep@v1.0.0",
+	}
+	reversed := make([]string, len(edges))
+	for i, edge := range edges {
+		reversed[len(edges)-1-i] = edge
+	}
+
+	first, err := ParseGraph(strings.NewReader(strings.Join(edges, "\n")))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+	second, err := ParseGraph(strings.NewReader(strings.Join(reversed, "\n")))
+	if err != nil {
+		t.Fatalf("ParseGraph() error = %v", err)
+	}
+
+	first.Canonicalize()
+	second.Canonicalize()
+
+	renderers := []Renderer{
+		NewPlaintextRenderer(),
+		NewMermaidRenderer(),
+		NewGraphvizRenderer(),
+		NewHTMLRenderer(),
+		NewCSVRenderer(),
+		&CSVRenderer{Edges: true},
+		NewJSONRenderer(),
+		NewSummaryRenderer(),
+	}
+
+	for _, renderer := range renderers {
+		var a, b bytes.Buffer
+		if err := renderer.Render(first, &a); err != nil {
+			t.Fatalf("%T.Render() error = %v", renderer, err)
+		}
+		if err := renderer.Render(second, &b); err != nil {
+			t.Fatalf("%T.Render() error = %v", renderer, err)
+		}
+		if !bytes.Equal(a.Bytes(), b.Bytes()) {
+			t.Errorf("%T output differs between input orders after Canonicalize", renderer)
+		}
+	}
+}
+
+func TestDependencyGraph_AdjacencyMap(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	dep1 := Module{Path: "github.com/dep1", Version: "v1.0.0", License: "MIT"}
+	dep2 := Module{Path: "github.com/dep2", Version: "v2.0.0"}
+	subdep := Module{Path: "github.com/subdep", Version: "v1.0.0", Scope: ScopeTest}
+
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, dep1)
+	graph.AddDependency(mainModule, dep2)
+	graph.AddDependency(dep1, subdep)
+
+	want := map[Module][]Module{
+		mainModule: {dep1, dep2},
+		dep1:       {subdep},
+	}
+	adjacency := graph.AdjacencyMap()
+	if !reflect.DeepEqual(adjacency, want) {
+		t.Errorf("AdjacencyMap() = %v, want %v", adjacency, want)
+	}
+
+	adjacency[dep1] = nil
+	if len(graph.AdjacencyMap()[dep1]) != 1 {
+		t.Error("modifying the AdjacencyMap() result should not affect the graph")
+	}
+}
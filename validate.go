@@ -0,0 +1,90 @@
+package tangled
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IssueKind classifies a structural problem found by Validate
+type IssueKind string
+
+// Structural issue kinds reported by Validate
+const (
+	IssueCycle         IssueKind = "cycle"
+	IssueMultipleRoots IssueKind = "multiple-roots"
+	IssueDuplicateEdge IssueKind = "duplicate-edge"
+	IssueUnreachable   IssueKind = "unreachable"
+)
+
+// IssueKinds lists every issue kind in the order Validate reports them
+var IssueKinds = []IssueKind{IssueCycle, IssueMultipleRoots, IssueDuplicateEdge, IssueUnreachable}
+
+// ValidationIssue describes a single structural problem in a graph
+type ValidationIssue struct {
+	Kind    IssueKind
+	Message string
+}
+
+// String returns the issue as "kind: message"
+func (vi ValidationIssue) String() string {
+	return fmt.Sprintf("%s: %s", vi.Kind, vi.Message)
+}
+
+// Validate checks the graph for cycles, modules other than the main module
+// that nothing depends on, repeated edges and modules unreachable from the
+// main module. Issues are grouped by kind in IssueKinds order.
+func (dg *DependencyGraph) Validate() []ValidationIssue {
+	var issues []ValidationIssue
+
+	for _, cycle := range dg.DetectCycles() {
+		parts := make([]string, len(cycle))
+		for i, module := range cycle {
+			parts[i] = module.String()
+		}
+		issues = append(issues, ValidationIssue{Kind: IssueCycle, Message: strings.Join(parts, " -> ")})
+	}
+
+	inDegree, _ := dg.DegreeMaps()
+	mainStr := dg.MainModule.String()
+	var roots []string
+	for _, module := range dg.GetAllModules() {
+		if moduleStr := module.String(); moduleStr != mainStr && inDegree[moduleStr] == 0 {
+			roots = append(roots, moduleStr)
+		}
+	}
+	if len(roots) > 0 {
+		issues = append(issues, ValidationIssue{
+			Kind:    IssueMultipleRoots,
+			Message: fmt.Sprintf("modules besides %s with no dependents: %s", mainStr, strings.Join(roots, ", ")),
+		})
+	}
+
+	counts := dg.EdgeCounts()
+	var duplicates []string
+	for key, count := range counts {
+		if count > 1 {
+			duplicates = append(duplicates, key)
+		}
+	}
+	sort.Strings(duplicates)
+	for _, key := range duplicates {
+		from, to, _ := strings.Cut(key, " ")
+		issues = append(issues, ValidationIssue{
+			Kind:    IssueDuplicateEdge,
+			Message: fmt.Sprintf("%s -> %s appears %d times", from, to, counts[key]),
+		})
+	}
+
+	depths := dg.DepthMap()
+	for _, module := range dg.GetAllModules() {
+		if _, ok := depths[module.String()]; !ok {
+			issues = append(issues, ValidationIssue{
+				Kind:    IssueUnreachable,
+				Message: fmt.Sprintf("%s is not reachable from %s", module.String(), mainStr),
+			})
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,38 @@
+package tangled
+
+import "testing"
+
+func TestDependencyGraph_Validate(t *testing.T) {
+	t.Run("clean graph", func(t *testing.T) {
+		if issues := createTestGraph().Validate(); len(issues) != 0 {
+			t.Errorf("Validate() = %v, want no issues", issues)
+		}
+	})
+
+	t.Run("every issue kind", func(t *testing.T) {
+		graph := createTestGraph()
+		dep1 := Module{Path: "github.com/dep1", Version: "v1.0.0"}
+		subdep := Module{Path: "github.com/subdep", Version: "v1.0.0"}
+		orphan := Module{Path: "github.com/orphan", Version: "v1.0.0"}
+		graph.AddDependency(subdep, dep1)
+		graph.AddDependency(graph.MainModule, dep1)
+		graph.AddDependency(orphan, subdep)
+
+		kinds := make(map[IssueKind]int)
+		for _, issue := range graph.Validate() {
+			kinds[issue.Kind]++
+		}
+		for _, kind := range IssueKinds {
+			if kinds[kind] != 1 {
+				t.Errorf("%s issues = %d, want 1", kind, kinds[kind])
+			}
+		}
+	})
+}
+
+func TestValidationIssue_String(t *testing.T) {
+	issue := ValidationIssue{Kind: IssueUnreachable, Message: "a is not reachable from main"}
+	if got, want := issue.String(), "unreachable: a is not reachable from main"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,354 @@
+package tangled
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parsedVersion holds the comparable parts of a semantic version
+type parsedVersion struct {
+	numbers    [3]int
+	prerelease []string
+}
+
+// parseVersion parses a "vMAJOR[.MINOR[.PATCH]][-prerelease][+build]" version string
+func parseVersion(version string) (parsedVersion, bool) {
+	var pv parsedVersion
+	if !strings.HasPrefix(version, "v") {
+		return pv, false
+	}
+
+	v := version[1:]
+	if idx := strings.Index(v, "+"); idx != -1 {
+		v = v[:idx]
+	}
+	if idx := strings.Index(v, "-"); idx != -1 {
+		pv.prerelease = strings.Split(v[idx+1:], ".")
+		v = v[:idx]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return pv, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return pv, false
+		}
+		pv.numbers[i] = n
+	}
+
+	return pv, true
+}
+
+// compareVersions orders two module versions by semantic version precedence,
+// returning -1, 0 or 1. Invalid versions sort before valid ones and are
+// compared as plain strings among themselves.
+func compareVersions(a, b string) int {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+
+	switch {
+	case !okA && !okB:
+		return strings.Compare(a, b)
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+
+	for i := range pa.numbers {
+		if pa.numbers[i] != pb.numbers[i] {
+			if pa.numbers[i] < pb.numbers[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A release has higher precedence than any of its prereleases
+	switch {
+	case len(pa.prerelease) == 0 && len(pb.prerelease) == 0:
+		return 0
+	case len(pa.prerelease) == 0:
+		return 1
+	case len(pb.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(pa.prerelease) && i < len(pb.prerelease); i++ {
+		if c := comparePrereleaseIdentifier(pa.prerelease[i], pb.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(pa.prerelease) < len(pb.prerelease):
+		return -1
+	case len(pa.prerelease) > len(pb.prerelease):
+		return 1
+	}
+	return 0
+}
+
+// comparePrereleaseIdentifier compares numeric identifiers numerically and
+// others lexically, with numeric identifiers sorting first
+func comparePrereleaseIdentifier(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		if na < nb {
+			return -1
+		} else if na > nb {
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// PseudoVersionTime returns the commit time embedded in a pseudo-version such
+// as v0.0.0-20210101150405-abcdef123456, in UTC. All three pseudo-version
+// forms are recognized; ok is false for any other version.
+func PseudoVersionTime(version string) (time.Time, bool) {
+	if idx := strings.Index(version, "+"); idx != -1 {
+		version = version[:idx]
+	}
+	parts := strings.Split(version, "-")
+	if len(parts) < 3 || !strings.HasPrefix(parts[0], "v") || !isRevision(parts[len(parts)-1]) {
+		return time.Time{}, false
+	}
+
+	// The timestamp follows the last dot in forms like v1.2.4-0.20210101150405-rev
+	timestamp := parts[len(parts)-2]
+	if idx := strings.LastIndex(timestamp, "."); idx != -1 {
+		timestamp = timestamp[idx+1:]
+	}
+	if len(timestamp) != 14 {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("20060102150405", timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// isRevision reports whether s looks like the 12-character commit hash of a pseudo-version
+func isRevision(s string) bool {
+	if len(s) != 12 {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectedVersions returns the highest version present for each module path,
+// mirroring the version minimal version selection would pick from the graph
+func (dg *DependencyGraph) SelectedVersions() map[string]string {
+	selected := make(map[string]string)
+	for _, module := range dg.GetAllModules() {
+		current, ok := selected[module.Path]
+		if !ok || compareVersions(module.Version, current) > 0 {
+			selected[module.Path] = module.Version
+		}
+	}
+	return selected
+}
+
+// MajorVersionSpread returns, for each module path, the distinct major
+// versions present in the graph in ascending order. Paths are keyed without
+// their semantic import version suffix, so github.com/foo@v1.2.0 and
+// github.com/foo/v2@v2.0.0 both count towards github.com/foo. Modules
+// without a valid version are ignored.
+func (dg *DependencyGraph) MajorVersionSpread() map[string][]int {
+	majors := make(map[string]map[int]bool)
+	for _, module := range dg.GetAllModules() {
+		pv, ok := parseVersion(module.Version)
+		if !ok {
+			continue
+		}
+		path := trimMajorSuffix(module.Path)
+		if majors[path] == nil {
+			majors[path] = make(map[int]bool)
+		}
+		majors[path][pv.numbers[0]] = true
+	}
+
+	spread := make(map[string][]int, len(majors))
+	for path, set := range majors {
+		for major := range set {
+			spread[path] = append(spread[path], major)
+		}
+		sort.Ints(spread[path])
+	}
+	return spread
+}
+
+// trimMajorSuffix removes a "/vN" major version suffix (N >= 2) from a module path
+func trimMajorSuffix(path string) string {
+	stem, _ := splitMajorSuffix(path)
+	return stem
+}
+
+// splitMajorSuffix splits a module path into its stem and the major version
+// its "/vN" suffix implies, which is 1 for paths without a suffix
+func splitMajorSuffix(path string) (string, int) {
+	idx := strings.LastIndex(path, "/v")
+	if idx == -1 {
+		return path, 1
+	}
+	n, err := strconv.Atoi(path[idx+2:])
+	if err != nil || n < 2 || strconv.Itoa(n) != path[idx+2:] {
+		return path, 1
+	}
+	return path[:idx], n
+}
+
+// CrossesMajor reports whether the edge joins two major versions of the same
+// module, such as github.com/foo to github.com/foo/v2. Such edges are often
+// left behind by a partial v1 to v2 migration.
+func (d Dependency) CrossesMajor() bool {
+	fromStem, fromMajor := splitMajorSuffix(d.From.Path)
+	toStem, toMajor := splitMajorSuffix(d.To.Path)
+	return fromStem == toStem && fromMajor != toMajor
+}
+
+// VersionRequest records the modules that require a particular version of a dependency
+type VersionRequest struct {
+	Version    string
+	Requesters []Module
+}
+
+// IndirectConflict records a module path required at more than one version
+type IndirectConflict struct {
+	Path     string
+	Requests []VersionRequest // ordered from lowest to highest version
+}
+
+// String returns a one-line description of the conflict
+func (ic IndirectConflict) String() string {
+	parts := make([]string, len(ic.Requests))
+	for i, req := range ic.Requests {
+		requesters := make([]string, len(req.Requesters))
+		for j, module := range req.Requesters {
+			requesters[j] = module.String()
+		}
+		parts[i] = fmt.Sprintf("%s (%s)", req.Version, strings.Join(requesters, ", "))
+	}
+	return fmt.Sprintf("%s: %s", ic.Path, strings.Join(parts, "; "))
+}
+
+// FindIndirectConflicts returns every module path that is required at more
+// than one version, with the modules requesting each version, sorted by path
+func (dg *DependencyGraph) FindIndirectConflicts() []IndirectConflict {
+	requesters := make(map[string]map[string]map[string]Module) // path -> version -> requester string -> requester
+	for _, dep := range dg.Dependencies {
+		versions := requesters[dep.To.Path]
+		if versions == nil {
+			versions = make(map[string]map[string]Module)
+			requesters[dep.To.Path] = versions
+		}
+		if versions[dep.To.Version] == nil {
+			versions[dep.To.Version] = make(map[string]Module)
+		}
+		versions[dep.To.Version][dep.From.String()] = dep.From
+	}
+
+	var conflicts []IndirectConflict
+	for path, versions := range requesters {
+		if len(versions) < 2 {
+			continue
+		}
+
+		conflict := IndirectConflict{Path: path}
+		for version, byKey := range versions {
+			req := VersionRequest{Version: version}
+			for _, module := range byKey {
+				req.Requesters = append(req.Requesters, module)
+			}
+			sort.Slice(req.Requesters, func(i, j int) bool {
+				return req.Requesters[i].String() < req.Requesters[j].String()
+			})
+			conflict.Requests = append(conflict.Requests, req)
+		}
+		sort.Slice(conflict.Requests, func(i, j int) bool {
+			return compareVersions(conflict.Requests[i].Version, conflict.Requests[j].Version) < 0
+		})
+		conflicts = append(conflicts, conflict)
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Path < conflicts[j].Path
+	})
+	return conflicts
+}
+
+// OrphanVersion records a module version that minimal version selection
+// passes over in favour of a higher one but that other modules still require
+type OrphanVersion struct {
+	Module     Module
+	Selected   string   // the version selected for the module's path
+	Requesters []Module // sorted by module string
+}
+
+// String returns a one-line description of the orphaned version
+func (ov OrphanVersion) String() string {
+	requesters := make([]string, len(ov.Requesters))
+	for i, module := range ov.Requesters {
+		requesters[i] = module.String()
+	}
+	return fmt.Sprintf("%s: selected %s, still required by %s", ov.Module, ov.Selected, strings.Join(requesters, ", "))
+}
+
+// OrphanVersions returns every module version with incoming edges whose path
+// resolves to a different version under SelectedVersions, along with the
+// modules still requiring it, sorted by module. These are the older versions
+// that linger in go mod graph output although the build never uses them.
+func (dg *DependencyGraph) OrphanVersions() []OrphanVersion {
+	selected := dg.SelectedVersions()
+	requesters := make(map[string]map[string]Module) // orphan string -> requester string -> requester
+	orphans := make(map[string]Module)
+	for _, dep := range dg.Dependencies {
+		if dep.To.Version == selected[dep.To.Path] {
+			continue
+		}
+		key := dep.To.String()
+		if requesters[key] == nil {
+			requesters[key] = make(map[string]Module)
+			orphans[key] = dep.To
+		}
+		requesters[key][dep.From.String()] = dep.From
+	}
+
+	result := make([]OrphanVersion, 0, len(orphans))
+	for key, module := range orphans {
+		orphan := OrphanVersion{Module: module, Selected: selected[module.Path]}
+		for _, requester := range requesters[key] {
+			orphan.Requesters = append(orphan.Requesters, requester)
+		}
+		sort.Slice(orphan.Requesters, func(i, j int) bool {
+			return orphan.Requesters[i].String() < orphan.Requesters[j].String()
+		})
+		result = append(result, orphan)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Module.String() < result[j].Module.String()
+	})
+	return result
+}
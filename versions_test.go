@@ -0,0 +1,198 @@
+package tangled
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.2.0", "v1.10.0", -1},
+		{"v2.0.0", "v1.9.9", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-alpha", "v1.0.0-beta", -1},
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1},
+		{"v1.0.0-alpha", "v1.0.0-alpha.1", -1},
+		{"v0.0.0-20210101000000-abcdef123456", "v0.1.0", -1},
+		{"v2.0.0+incompatible", "v1.5.0", 1},
+		{"", "v0.1.0", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := compareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := compareVersions(tt.b, tt.a); got != -tt.want {
+				t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencyGraph_SelectedVersions(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, Module{Path: "github.com/dep", Version: "v1.2.0"})
+	graph.AddDependency(mainModule, Module{Path: "github.com/other", Version: "v0.1.0"})
+	graph.AddDependency(Module{Path: "github.com/other", Version: "v0.1.0"}, Module{Path: "github.com/dep", Version: "v1.10.0"})
+
+	selected := graph.SelectedVersions()
+	if selected["github.com/dep"] != "v1.10.0" {
+		t.Errorf("selected version of dep = %q, want v1.10.0", selected["github.com/dep"])
+	}
+	if selected["github.com/example/main"] != "" {
+		t.Errorf("main module should have no version, got %q", selected["github.com/example/main"])
+	}
+}
+
+func TestDependencyGraph_FindIndirectConflicts(t *testing.T) {
+	main := Module{Path: "example.com/main"}
+	a := Module{Path: "example.com/a", Version: "v1.0.0"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	shared11 := Module{Path: "example.com/shared", Version: "v1.1.0"}
+	shared12 := Module{Path: "example.com/shared", Version: "v1.2.0"}
+
+	graph := NewDependencyGraph(main)
+	graph.AddDependency(main, a)
+	graph.AddDependency(main, b)
+	graph.AddDependency(a, shared12)
+	graph.AddDependency(b, shared11)
+
+	conflicts := graph.FindIndirectConflicts()
+	if len(conflicts) != 1 {
+		t.Fatalf("FindIndirectConflicts() = %v, want one conflict", conflicts)
+	}
+
+	conflict := conflicts[0]
+	if conflict.Path != "example.com/shared" || len(conflict.Requests) != 2 {
+		t.Fatalf("conflict = %+v, want two requested versions of example.com/shared", conflict)
+	}
+	if req := conflict.Requests[0]; req.Version != "v1.1.0" || len(req.Requesters) != 1 || req.Requesters[0] != b {
+		t.Errorf("first request = %+v, want v1.1.0 from %v", req, b)
+	}
+	if req := conflict.Requests[1]; req.Version != "v1.2.0" || len(req.Requesters) != 1 || req.Requesters[0] != a {
+		t.Errorf("second request = %+v, want v1.2.0 from %v", req, a)
+	}
+
+	want := "example.com/shared: v1.1.0 (example.com/b@v1.0.0); v1.2.0 (example.com/a@v1.0.0)"
+	if conflict.String() != want {
+		t.Errorf("String() = %q, want %q", conflict.String(), want)
+	}
+}
+
+func TestDependencyGraph_OrphanVersions(t *testing.T) {
+	main := Module{Path: "example.com/main"}
+	a := Module{Path: "example.com/a", Version: "v1.0.0"}
+	b := Module{Path: "example.com/b", Version: "v1.0.0"}
+	shared10 := Module{Path: "example.com/shared", Version: "v1.0.0"}
+	shared12 := Module{Path: "example.com/shared", Version: "v1.2.0"}
+
+	graph := NewDependencyGraph(main)
+	graph.AddDependency(main, a)
+	graph.AddDependency(main, b)
+	graph.AddDependency(main, shared12)
+	graph.AddDependency(b, shared10)
+	graph.AddDependency(a, shared10)
+
+	orphans := graph.OrphanVersions()
+	if len(orphans) != 1 {
+		t.Fatalf("OrphanVersions() = %v, want only the older shared version", orphans)
+	}
+
+	orphan := orphans[0]
+	if orphan.Module != shared10 || orphan.Selected != "v1.2.0" {
+		t.Errorf("orphan = %+v, want %v passed over for v1.2.0", orphan, shared10)
+	}
+	if len(orphan.Requesters) != 2 || orphan.Requesters[0] != a || orphan.Requesters[1] != b {
+		t.Errorf("Requesters = %v, want [%v %v]", orphan.Requesters, a, b)
+	}
+
+	want := "example.com/shared@v1.0.0: selected v1.2.0, still required by example.com/a@v1.0.0, example.com/b@v1.0.0"
+	if orphan.String() != want {
+		t.Errorf("String() = %q, want %q", orphan.String(), want)
+	}
+}
+
+func TestDependencyGraph_MajorVersionSpread(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, Module{Path: "github.com/dep", Version: "v1.2.0"})
+	graph.AddDependency(mainModule, Module{Path: "github.com/dep", Version: "v2.0.0+incompatible"})
+	graph.AddDependency(mainModule, Module{Path: "github.com/lib", Version: "v1.0.0"})
+	graph.AddDependency(mainModule, Module{Path: "github.com/lib/v3", Version: "v3.1.0"})
+	graph.AddDependency(mainModule, Module{Path: "github.com/single", Version: "v0.1.0"})
+	graph.AddDependency(mainModule, Module{Path: "github.com/single", Version: "v0.2.0"})
+
+	spread := graph.MajorVersionSpread()
+	if got := spread["github.com/dep"]; len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("github.com/dep spread = %v, want [1 2]", got)
+	}
+	if got := spread["github.com/lib"]; len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("github.com/lib spread = %v, want [1 3]", got)
+	}
+	if got := spread["github.com/single"]; len(got) != 1 || got[0] != 0 {
+		t.Errorf("github.com/single spread = %v, want [0]", got)
+	}
+	if _, ok := spread["github.com/example/main"]; ok {
+		t.Error("modules without a version should be ignored")
+	}
+}
+
+func TestDependency_CrossesMajor(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"github.com/foo", "github.com/foo/v2", true},
+		{"github.com/foo/v2", "github.com/foo/v3", true},
+		{"github.com/foo/v2", "github.com/foo", true},
+		{"github.com/foo", "github.com/foo", false},
+		{"github.com/foo", "github.com/bar/v2", false},
+		{"github.com/foo", "github.com/foo/vendor", false},
+	}
+
+	for _, tt := range tests {
+		dep := Dependency{From: Module{Path: tt.from}, To: Module{Path: tt.to, Version: "v2.0.0"}}
+		if got := dep.CrossesMajor(); got != tt.want {
+			t.Errorf("%s -> %s CrossesMajor() = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestPseudoVersionTime(t *testing.T) {
+	want := time.Date(2021, 3, 15, 14, 30, 5, 0, time.UTC)
+	tests := []struct {
+		version string
+		ok      bool
+	}{
+		{version: "v0.0.0-20210315143005-abcdef123456", ok: true},
+		{version: "v1.2.4-0.20210315143005-abcdef123456", ok: true},
+		{version: "v1.2.3-pre.0.20210315143005-abcdef123456", ok: true},
+		{version: "v2.0.0-20210315143005-abcdef123456+incompatible", ok: true},
+		{version: "v1.2.3"},
+		{version: "v1.0.0-rc.1"},
+		{version: "v0.0.0-20211315143005-abcdef123456"},
+		{version: "v0.0.0-20210315143005-nothexrevxyz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, ok := PseudoVersionTime(tt.version)
+			if ok != tt.ok {
+				t.Fatalf("PseudoVersionTime(%q) ok = %v, want %v", tt.version, ok, tt.ok)
+			}
+			if ok && !got.Equal(want) {
+				t.Errorf("PseudoVersionTime(%q) = %v, want %v", tt.version, got, want)
+			}
+		})
+	}
+
+	if date, ok := (Module{Path: "github.com/dep", Version: "v0.0.0-20210315143005-abcdef123456"}).Date(); !ok || !date.Equal(want) {
+		t.Errorf("Module.Date() = %v, %v, want %v", date, ok, want)
+	}
+}
@@ -0,0 +1,124 @@
+package tangled
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// View is a reusable pipeline of filters read from a view file. Its steps are
+// applied in order, each to the result of the one before.
+//
+// A view file is JSON of the form:
+//
+//	{
+//	  "steps": [
+//	    {"exclude": ["golang.org/x/"]},
+//	    {"include": ["github.com/mycorp/"]},
+//	    {"focus": "github.com/mycorp/api", "depth": 2},
+//	    {"depth": 3}
+//	  ]
+//	}
+type View struct {
+	Steps []ViewStep `json:"steps"`
+}
+
+// ViewStep is a single filter in a View. Exactly one of Exclude, Include or
+// Focus may be set; Depth is the radius of a Focus step (default 1), or on
+// its own limits the graph to modules within that many hops of the main
+// module.
+type ViewStep struct {
+	// Exclude removes modules matching these path prefixes, as Exclude does
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Include keeps only modules matching these path prefixes, as Include does
+	Include []string `json:"include,omitempty"`
+
+	// Focus keeps the ego network around this module, given as path or
+	// path@version
+	Focus string `json:"focus,omitempty"`
+
+	Depth int `json:"depth,omitempty"`
+}
+
+// ParseViewFromFile reads a view file
+func ParseViewFromFile(filename string) (*View, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseView(file)
+}
+
+// ParseView decodes a view from JSON, rejecting unknown fields and steps
+// that do not hold exactly one filter
+func ParseView(reader io.Reader) (*View, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+
+	var view View
+	if err := decoder.Decode(&view); err != nil {
+		return nil, fmt.Errorf("invalid view: %w", err)
+	}
+	for i, step := range view.Steps {
+		if err := step.validate(); err != nil {
+			return nil, fmt.Errorf("invalid view step %d: %w", i+1, err)
+		}
+	}
+	return &view, nil
+}
+
+func (s ViewStep) validate() error {
+	filters := 0
+	for _, set := range []bool{len(s.Exclude) > 0, len(s.Include) > 0, s.Focus != ""} {
+		if set {
+			filters++
+		}
+	}
+	switch {
+	case s.Depth < 0:
+		return fmt.Errorf("depth must not be negative, got %d", s.Depth)
+	case filters > 1:
+		return fmt.Errorf("step must hold only one of exclude, include or focus")
+	case filters == 0 && s.Depth == 0:
+		return fmt.Errorf("step must hold exclude, include, focus or depth")
+	case filters == 1 && s.Depth != 0 && s.Focus == "":
+		return fmt.Errorf("depth can only be combined with focus")
+	}
+	return nil
+}
+
+// Apply returns the graph produced by running every step of the view in order
+func (v *View) Apply(graph *DependencyGraph) (*DependencyGraph, error) {
+	for i, step := range v.Steps {
+		var err error
+		if graph, err = step.apply(graph); err != nil {
+			return nil, fmt.Errorf("view step %d: %w", i+1, err)
+		}
+	}
+	return graph, nil
+}
+
+func (s ViewStep) apply(graph *DependencyGraph) (*DependencyGraph, error) {
+	switch {
+	case len(s.Exclude) > 0:
+		return graph.Exclude(s.Exclude), nil
+	case len(s.Include) > 0:
+		return graph.Include(s.Include), nil
+	case s.Focus != "":
+		center, ok := graph.FindModule(s.Focus)
+		if !ok {
+			return nil, fmt.Errorf("module not found in graph: %s", s.Focus)
+		}
+		radius := s.Depth
+		if radius == 0 {
+			radius = 1
+		}
+		return graph.EgoNetwork(center, radius)
+	default:
+		return graph.LimitDepth(s.Depth), nil
+	}
+}
@@ -0,0 +1,48 @@
+package tangled
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseView(t *testing.T) {
+	input := `{"steps": [{"exclude": ["golang.org/x/"]}, {"focus": "github.com/dep1", "depth": 2}, {"depth": 3}]}`
+
+	view, err := ParseView(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseView() error = %v", err)
+	}
+	if len(view.Steps) != 3 || view.Steps[1].Focus != "github.com/dep1" || view.Steps[1].Depth != 2 {
+		t.Errorf("ParseView() = %+v", view)
+	}
+
+	invalid := []string{
+		`{"steps": [{}]}`,
+		`{"steps": [{"exclude": ["a"], "include": ["b"]}]}`,
+		`{"steps": [{"exclude": ["a"], "depth": 1}]}`,
+		`{"steps": [{"depth": -1}]}`,
+		`{"steps": [{"prefix": "a"}]}`,
+	}
+	for _, input := range invalid {
+		if _, err := ParseView(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseView(%s) should fail", input)
+		}
+	}
+}
+
+func TestView_Apply(t *testing.T) {
+	graph := createTestGraph()
+	view := &View{Steps: []ViewStep{{Exclude: []string{"github.com/dep2"}}, {Depth: 1}}}
+
+	result, err := view.Apply(graph)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(result.Dependencies) != 1 || result.Dependencies[0].To.Path != "github.com/dep1" {
+		t.Errorf("Apply() kept %v, want only main -> dep1", result.Dependencies)
+	}
+
+	if _, err := (&View{Steps: []ViewStep{{Focus: "github.com/missing"}}}).Apply(graph); err == nil {
+		t.Error("Apply() should fail when the focus module is missing")
+	}
+}
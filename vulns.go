@@ -0,0 +1,94 @@
+package tangled
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// govulncheckMessage is the part of a govulncheck -json message that
+// records a finding; other message kinds decode with Finding nil
+type govulncheckMessage struct {
+	Finding *struct {
+		OSV   string `json:"osv"`
+		Trace []struct {
+			Module  string `json:"module"`
+			Version string `json:"version"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// ParseGovulncheckFromFile reads govulncheck -json output and returns
+// vulnerability IDs keyed by module path@version
+func ParseGovulncheckFromFile(filename string) (map[string][]string, error) {
+	file, err := os.Open(filename) // #nosec G304 -- CLI tool, filename from user-provided command line flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseGovulncheck(file)
+}
+
+// ParseGovulncheck reads the stream of JSON messages written by
+// govulncheck -json and returns the IDs of the vulnerabilities found in each
+// module, keyed by module path@version, or by path when the finding has no
+// version, with each module's IDs sorted and listed once. The first frame of
+// a finding's trace names the vulnerable module; messages other than
+// findings are ignored.
+func ParseGovulncheck(reader io.Reader) (map[string][]string, error) {
+	decoder := json.NewDecoder(reader)
+	found := make(map[string]map[string]bool)
+
+	for {
+		var message govulncheckMessage
+		if err := decoder.Decode(&message); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid govulncheck JSON: %w", err)
+		}
+
+		finding := message.Finding
+		if finding == nil || finding.OSV == "" || len(finding.Trace) == 0 || finding.Trace[0].Module == "" {
+			continue
+		}
+		key := Module{Path: finding.Trace[0].Module, Version: finding.Trace[0].Version}.String()
+		if found[key] == nil {
+			found[key] = make(map[string]bool)
+		}
+		found[key][finding.OSV] = true
+	}
+
+	vulns := make(map[string][]string, len(found))
+	for key, ids := range found {
+		for id := range ids {
+			vulns[key] = append(vulns[key], id)
+		}
+		sort.Strings(vulns[key])
+	}
+	return vulns, nil
+}
+
+// ApplyVulnerabilities sets the Vulns of every module in the graph from a map
+// of vulnerability IDs keyed by module path@version, falling back to an
+// entry keyed by path alone
+func (dg *DependencyGraph) ApplyVulnerabilities(vulns map[string][]string) {
+	attach := func(module Module) Module {
+		ids, ok := vulns[module.String()]
+		if !ok {
+			ids = vulns[module.Path]
+		}
+		module.Vulns = strings.Join(ids, ", ")
+		return module
+	}
+
+	dg.MainModule = attach(dg.MainModule)
+	for i, dep := range dg.Dependencies {
+		dg.Dependencies[i].From = attach(dep.From)
+		dg.Dependencies[i].To = attach(dep.To)
+	}
+}
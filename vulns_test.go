@@ -0,0 +1,77 @@
+package tangled
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// govulncheckOutput is trimmed govulncheck -json output with two findings in
+// golang.org/x/net, one repeated at another scan level, and one in stdlib
+const govulncheckOutput = `{"config": {"protocol_version": "v1.0.0", "scanner_name": "govulncheck"}}
+{"progress": {"message": "Scanning your code and 46 packages across 1 dependent module for known vulnerabilities..."}}
+{"osv": {"id": "GO-2023-1571", "summary": "Denial of service in net/http and golang.org/x/net/http2"}}
+{"finding": {"osv": "GO-2023-1571", "fixed_version": "v0.7.0", "trace": [{"module": "golang.org/x/net", "version": "v0.1.0"}]}}
+{"finding": {"osv": "GO-2023-1571", "fixed_version": "v0.7.0", "trace": [{"module": "golang.org/x/net", "version": "v0.1.0", "package": "golang.org/x/net/http2"}]}}
+{"finding": {"osv": "GO-2022-1144", "fixed_version": "v0.4.0", "trace": [{"module": "golang.org/x/net", "version": "v0.1.0"}, {"module": "github.com/example/main"}]}}
+{"finding": {"osv": "GO-2024-2600", "trace": [{"module": "stdlib", "version": "v1.21.0"}]}}
+`
+
+func TestParseGovulncheck(t *testing.T) {
+	vulns, err := ParseGovulncheck(strings.NewReader(govulncheckOutput))
+	if err != nil {
+		t.Fatalf("ParseGovulncheck() error = %v", err)
+	}
+
+	want := map[string][]string{
+		"golang.org/x/net@v0.1.0": {"GO-2022-1144", "GO-2023-1571"},
+		"stdlib@v1.21.0":          {"GO-2024-2600"},
+	}
+	if !reflect.DeepEqual(vulns, want) {
+		t.Errorf("ParseGovulncheck() = %v, want %v", vulns, want)
+	}
+
+	if _, err := ParseGovulncheck(strings.NewReader(`{"finding": `)); err == nil {
+		t.Error("ParseGovulncheck() should reject truncated JSON")
+	}
+}
+
+func TestDependencyGraph_ApplyVulnerabilities(t *testing.T) {
+	mainModule := Module{Path: "github.com/example/main"}
+	net := Module{Path: "golang.org/x/net", Version: "v0.1.0"}
+	text := Module{Path: "golang.org/x/text", Version: "v0.3.0"}
+	graph := NewDependencyGraph(mainModule)
+	graph.AddDependency(mainModule, net)
+	graph.AddDependency(net, text)
+
+	graph.ApplyVulnerabilities(map[string][]string{"golang.org/x/net@v0.1.0": {"GO-2022-1144", "GO-2023-1571"}})
+
+	vulns := make(map[string]string)
+	for _, module := range graph.GetAllModules() {
+		vulns[module.Path] = module.Vulns
+	}
+	want := map[string]string{"github.com/example/main": "", "golang.org/x/net": "GO-2022-1144, GO-2023-1571", "golang.org/x/text": ""}
+	if !reflect.DeepEqual(vulns, want) {
+		t.Errorf("module Vulns = %v, want %v", vulns, want)
+	}
+
+	var dot bytes.Buffer
+	if err := NewGraphvizRenderer().Render(graph, &dot); err != nil {
+		t.Fatalf("GraphvizRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(dot.String(), `"golang_org_x_net_v0_1_0" [label="golang.org/x/net@v0.1.0", color="red", fontcolor="red", tooltip="golang.org/x/net@v0.1.0: GO-2022-1144, GO-2023-1571"];`) {
+		t.Errorf("vulnerable module should be drawn in red with its IDs, got:\n%s", dot.String())
+	}
+	if !strings.Contains(dot.String(), `"golang_org_x_text_v0_3_0" [label="golang.org/x/text@v0.3.0"];`) {
+		t.Errorf("other modules should stay unstyled, got:\n%s", dot.String())
+	}
+
+	var html bytes.Buffer
+	if err := NewHTMLRenderer().Render(graph, &html); err != nil {
+		t.Fatalf("HTMLRenderer.Render() error = %v", err)
+	}
+	if !strings.Contains(html.String(), `"vulns": "GO-2022-1144, GO-2023-1571"`) {
+		t.Error("HTML node data should carry the vulnerability IDs")
+	}
+}